@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type JSONFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	Items       []JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            JSONFeedID `json:"id"`
+	URL           string     `json:"url"`
+	Title         string     `json:"title"`
+	ContentHTML   string     `json:"content_html"`
+	ContentText   string     `json:"content_text"`
+	Summary       string     `json:"summary"`
+	DatePublished string     `json:"date_published"`
+	DateModified  string     `json:"date_modified"`
+	// version 1.1 has a list of authors, 1.0 a single one
+	Authors []JSONFeedAuthor `json:"authors"`
+	Author  *JSONFeedAuthor  `json:"author"`
+}
+
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// item ids should be strings, but some publishers use numbers
+type JSONFeedID string
+
+func (id *JSONFeedID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = JSONFeedID(s)
+		return nil
+	}
+	*id = JSONFeedID(strings.TrimSpace(string(data)))
+	return nil
+}
+
+// convert a JSON feed into the RSSFeed model used by scrapeFeeds
+func (j JSONFeed) toRSSFeed() RSSFeed {
+	rss := RSSFeed{}
+	rss.Channel.Title = j.Title
+	rss.Channel.Link = j.HomePageURL
+	rss.Channel.Description = j.Description
+
+	for _, item := range j.Items {
+		// prefer the html content, then the text content, then the summary
+		description := item.ContentHTML
+		if description == "" {
+			description = item.ContentText
+		}
+		if description == "" {
+			description = item.Summary
+		}
+
+		// prefer the original publish date, fall back to the last modification
+		pubDate := item.DatePublished
+		if pubDate == "" {
+			pubDate = item.DateModified
+		}
+
+		rss.Channel.Item = append(rss.Channel.Item, RSSItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: description,
+			PubDate:     pubDate,
+			GUID:        string(item.ID),
+			Author:      item.authorNames(),
+		})
+	}
+	return rss
+}
+
+// join the names of the authors of an item
+func (item JSONFeedItem) authorNames() string {
+	authors := item.Authors
+	if len(authors) == 0 && item.Author != nil {
+		authors = []JSONFeedAuthor{*item.Author}
+	}
+	var names []string
+	for _, author := range authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
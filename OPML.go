@@ -0,0 +1,324 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title       string `xml:"title"`
+		DateCreated string `xml:"dateCreated,omitempty"`
+		OwnerName   string `xml:"ownerName,omitempty"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []OPMLOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// outlines with an xmlUrl are subscriptions, outlines with children are folders
+type OPMLOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Category string        `xml:"category,attr,omitempty"`
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// a subscription found in an OPML file and the folder it was in
+type opmlSubscription struct {
+	name     string
+	xmlURL   string
+	htmlURL  string
+	category string
+}
+
+// collect the subscriptions of nested outlines, folder names are joined with "/"
+func (o OPMLOutline) subscriptions(folder string, invalid *[]string) []opmlSubscription {
+	name := o.Title
+	if name == "" {
+		name = o.Text
+	}
+
+	// an outline without a feed url is a folder
+	if o.XMLURL == "" {
+		if len(o.Outlines) == 0 {
+			*invalid = append(*invalid, fmt.Sprintf("%q has no xmlUrl", name))
+			return nil
+		}
+		if folder != "" {
+			name = folder + "/" + name
+		}
+		var subs []opmlSubscription
+		for _, child := range o.Outlines {
+			subs = append(subs, child.subscriptions(name, invalid)...)
+		}
+		return subs
+	}
+
+	// only absolute http(s) urls can be fetched
+	feedURL, err := url.Parse(o.XMLURL)
+	if err != nil || (feedURL.Scheme != "http" && feedURL.Scheme != "https") || feedURL.Host == "" {
+		*invalid = append(*invalid, fmt.Sprintf("%q has an invalid xmlUrl %q", name, o.XMLURL))
+		return nil
+	}
+	if name == "" {
+		name = o.XMLURL
+	}
+
+	// outlines outside of a folder can still carry a category attribute
+	category := folder
+	if category == "" && o.Category != "" {
+		category = strings.Trim(strings.Split(o.Category, ",")[0], "/ ")
+	}
+
+	return []opmlSubscription{{
+		name:     name,
+		xmlURL:   o.XMLURL,
+		htmlURL:  o.HTMLURL,
+		category: category,
+	}}
+}
+
+// import subscriptions from an OPML file
+func handlerImport(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 2 || cmd.args[0] != "opml" {
+		return fmt.Errorf("import command requires the format opml and a file")
+	}
+
+	// read and parse the file
+	data, err := os.ReadFile(cmd.args[1])
+	if err != nil {
+		return err
+	}
+	opml := OPML{}
+	if err := xml.Unmarshal(data, &opml); err != nil {
+		return fmt.Errorf("parsing %s: %w", cmd.args[1], err)
+	}
+
+	// collect the subscriptions from every outline
+	var invalid []string
+	var subs []opmlSubscription
+	for _, outline := range opml.Body.Outlines {
+		subs = append(subs, outline.subscriptions("", &invalid)...)
+	}
+
+	// add each subscription, reporting problems instead of stopping
+	var imported, duplicates int
+	var failed []string
+	for _, sub := range subs {
+		isNew, err := importSubscription(s, user, sub)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%q: %v", sub.name, err))
+			continue
+		}
+		if !isNew {
+			duplicates++
+			fmt.Println("Already following:", sub.name)
+			continue
+		}
+		imported++
+		if sub.category != "" {
+			fmt.Printf("Followed: %s (%s)\n", sub.name, sub.category)
+		} else {
+			fmt.Println("Followed:", sub.name)
+		}
+	}
+
+	// print the report
+	fmt.Printf("Imported %d feeds, %d already followed, %d invalid, %d failed\n", imported, duplicates, len(invalid), len(failed))
+	for _, entry := range invalid {
+		fmt.Println("Invalid:", entry)
+	}
+	for _, entry := range failed {
+		fmt.Println("Failed:", entry)
+	}
+	return nil
+}
+
+// create the feed if it is missing and follow it, returns false if it was already followed
+func importSubscription(s *state, user database.User, sub opmlSubscription) (bool, error) {
+	timeNow := getNullTimeNow()
+	feedURL := sql.NullString{String: sub.xmlURL, Valid: true}
+
+	// create the feed if no one added it yet
+	feed, err := s.db.GetFeedByUrl(s.ctx, feedURL)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := s.db.CreateFeed(s.ctx, database.CreateFeedParams{
+			CreatedAt: timeNow,
+			UpdatedAt: timeNow,
+			Name:      sql.NullString{String: sub.name, Valid: true},
+			Url:       feedURL,
+			UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+			SiteUrl:   getNullString(sub.htmlURL),
+		}); err != nil {
+			return false, err
+		}
+		feed, err = s.db.GetFeedByUrl(s.ctx, feedURL)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// skip feeds the user already follows, including duplicates within the file
+	userID := uuid.NullUUID{UUID: user.ID, Valid: true}
+	feedID := sql.NullInt32{Int32: feed.ID, Valid: true}
+	_, err = s.db.GetFeedFollow(s.ctx, database.GetFeedFollowParams{UserID: userID, FeedID: feedID})
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	// follow the feed and file it in the folder of the outline
+	follow, err := s.db.CreateFeedFollow(s.ctx, database.CreateFeedFollowParams{
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+		UserID:    userID,
+		FeedID:    feedID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if sub.category == "" {
+		return true, nil
+	}
+	folder, err := getOrCreateFolder(s, user, sub.category)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.db.AddFeedFollowToFolder(s.ctx, database.AddFeedFollowToFolderParams{
+		FolderID:     folder.ID,
+		FeedFollowID: follow.ID,
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// export the feeds the user follows as OPML 2.0
+func handlerExport(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 || cmd.args[0] != "opml" {
+		return fmt.Errorf("export command requires the format opml and optionally a file")
+	}
+
+	// get the follows with their urls and folders
+	follows, err := s.db.GetFeedFollowsForUser(s.ctx, user.Name)
+	if err != nil {
+		return err
+	}
+
+	var subs []opmlSubscription
+	for _, follow := range follows {
+		sub := opmlSubscription{
+			name:    follow.FeedName.String,
+			xmlURL:  follow.FeedUrl.String,
+			htmlURL: follow.SiteUrl.String,
+		}
+		if len(follow.Folders) == 0 {
+			subs = append(subs, sub)
+		}
+		// a feed in several folders is listed in each of them
+		for _, folder := range follow.Folders {
+			sub.category = folder
+			subs = append(subs, sub)
+		}
+	}
+
+	// build the document
+	opml := OPML{Version: "2.0"}
+	opml.Head.Title = "gator subscriptions of " + user.Name
+	opml.Head.DateCreated = time.Now().Format(time.RFC1123Z)
+	opml.Head.OwnerName = user.Name
+	opml.Body.Outlines = opmlOutlines(subs)
+
+	data, err := xml.MarshalIndent(opml, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	data = append(data, '\n')
+
+	// write to the given file, or to stdout
+	if len(cmd.args) > 1 {
+		if err := os.WriteFile(cmd.args[1], data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d feeds to %s\n", len(subs), cmd.args[1])
+		return nil
+	}
+	s.wroteDocument = true
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// nest subscriptions into folder outlines by their category path
+func opmlOutlines(subs []opmlSubscription) []OPMLOutline {
+	paths := make([]string, len(subs))
+	for i, sub := range subs {
+		paths[i] = sub.category
+	}
+	return nestOutlines(subs, paths)
+}
+
+// place subscriptions with an empty path at this level and group the rest
+// into folders by the first element of their remaining path
+func nestOutlines(subs []opmlSubscription, paths []string) []OPMLOutline {
+	var outlines []OPMLOutline
+	var folderNames []string
+	folderSubs := map[string][]opmlSubscription{}
+	folderPaths := map[string][]string{}
+
+	for i, sub := range subs {
+		if paths[i] == "" {
+			outlines = append(outlines, sub.outline())
+			continue
+		}
+		name, rest, _ := strings.Cut(paths[i], "/")
+		if _, ok := folderSubs[name]; !ok {
+			folderNames = append(folderNames, name)
+		}
+		folderSubs[name] = append(folderSubs[name], sub)
+		folderPaths[name] = append(folderPaths[name], rest)
+	}
+
+	for _, name := range folderNames {
+		outlines = append(outlines, OPMLOutline{
+			Text:     name,
+			Title:    name,
+			Outlines: nestOutlines(folderSubs[name], folderPaths[name]),
+		})
+	}
+	return outlines
+}
+
+// get the outline of a subscription
+func (sub opmlSubscription) outline() OPMLOutline {
+	category := ""
+	if sub.category != "" {
+		category = "/" + sub.category
+	}
+	return OPMLOutline{
+		Text:     sub.name,
+		Title:    sub.name,
+		Type:     "rss",
+		XMLURL:   sub.xmlURL,
+		HTMLURL:  sub.htmlURL,
+		Category: category,
+	}
+}
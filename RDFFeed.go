@@ -0,0 +1,51 @@
+package main
+
+// RSS 1.0 documents keep their items next to the channel instead of inside it
+type RDFFeed struct {
+	Channel struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		// RSS 1.0 feeds hint their polling interval with the syndication module
+		UpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod"`
+		UpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency"`
+	} `xml:"channel"`
+	Items []RDFItem `xml:"item"`
+}
+
+type RDFItem struct {
+	About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+// convert an RSS 1.0 feed into the RSSFeed model used by scrapeFeeds
+func (r RDFFeed) toRSSFeed() RSSFeed {
+	rss := RSSFeed{}
+	rss.Channel.Title = r.Channel.Title
+	rss.Channel.Link = r.Channel.Link
+	rss.Channel.Description = r.Channel.Description
+	rss.Channel.UpdatePeriod = r.Channel.UpdatePeriod
+	rss.Channel.UpdateFrequency = r.Channel.UpdateFrequency
+
+	for _, item := range r.Items {
+		// the rdf:about uri identifies the item, fall back to the link
+		guid := item.About
+		if guid == "" {
+			guid = item.Link
+		}
+
+		rss.Channel.Item = append(rss.Channel.Item, RSSItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			PubDate:     item.Date,
+			GUID:        guid,
+			Author:      item.Creator,
+		})
+	}
+	return rss
+}
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"html"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type RSSFeed struct {
@@ -14,44 +21,168 @@ type RSSFeed struct {
 		Link        string    `xml:"link"`
 		Description string    `xml:"description"`
 		Item        []RSSItem `xml:"item"`
+		// how often the publisher says the feed should be polled
+		TTL             string `xml:"ttl"`
+		UpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod"`
+		UpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency"`
 	} `xml:"channel"`
 }
 
+// the syndication module update periods
+var updatePeriods = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+// get the polling interval hinted by <ttl> or <sy:updatePeriod>, zero if there is none
+func (r *RSSFeed) updateHint() time.Duration {
+	// the ttl is given in minutes
+	if ttl, err := strconv.Atoi(strings.TrimSpace(r.Channel.TTL)); err == nil && ttl > 0 {
+		return time.Duration(ttl) * time.Minute
+	}
+
+	// the period is divided by the number of updates per period, which defaults to one
+	period, ok := updatePeriods[strings.TrimSpace(r.Channel.UpdatePeriod)]
+	if !ok {
+		return 0
+	}
+	frequency, err := strconv.Atoi(strings.TrimSpace(r.Channel.UpdateFrequency))
+	if err != nil || frequency < 1 {
+		frequency = 1
+	}
+	return period / time.Duration(frequency)
+}
+
 type RSSItem struct {
 	Title       string `xml:"title"`
 	Link        string `xml:"link"`
 	Description string `xml:"description"`
 	PubDate     string `xml:"pubDate"`
+	GUID        string `xml:"guid"`
+	DCDate      string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Author      string `xml:"author"`
+	DCCreator   string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+// the cache validators a server sent with the last copy of a feed
+type feedCache struct {
+	ETag         string
+	LastModified string
+}
+
+// the outcome of fetching a feed
+type fetchResult struct {
+	Feed       *RSSFeed
+	StatusCode int
+	Cache      feedCache
+	MovedTo    string // set when the feed was permanently redirected to a new url
+	Bytes      int
 }
 
-func fetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
+// the error returned when a feed request gets a non-2xx response
+type httpStatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("fetching %s: %s", e.URL, e.Status)
+}
+
+// check if the request might succeed if tried again later
+func (e *httpStatusError) temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// check if the server reported the feed as unchanged since the last fetch
+func (r *fetchResult) notModified() bool {
+	return r.StatusCode == http.StatusNotModified
+}
+
+func fetchFeed(ctx context.Context, feedURL string, cache feedCache) (*fetchResult, error) {
 	rss := RSSFeed{}
+	result := fetchResult{Feed: &rss, Cache: cache}
 
 	// make the request string
-	req, err := http.NewRequestWithContext(context.Background(), "GET", feedURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
 	if err != nil {
-		return &rss, err
+		return &result, err
 	}
 	// set the headers
 	req.Header.Set("User-Agent", "gator")
 
+	// ask the server to only send the feed if it changed since the last fetch
+	if cache.ETag != "" {
+		req.Header.Set("If-None-Match", cache.ETag)
+	}
+	if cache.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cache.LastModified)
+	}
+
+	// follow redirects, remembering the new url if every hop was permanent
+	permanent := true
+	client := http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			code := req.Response.StatusCode
+			permanent = permanent && (code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect)
+			if permanent {
+				result.MovedTo = req.URL.String()
+			}
+			return nil
+		},
+	}
+
 	// make the request
-	client := http.Client{}
 	res, err := client.Do(req)
 	if err != nil {
-		return &rss, err
+		return &result, err
 	}
 	defer res.Body.Close()
+	result.StatusCode = res.StatusCode
+
+	// error pages are not feeds, don't try to parse them
+	if !result.notModified() && (res.StatusCode < 200 || res.StatusCode > 299) {
+		return &result, &httpStatusError{
+			URL:        res.Request.URL.String(),
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+		}
+	}
+
+	// nothing to parse, keep the old validators unless the server sent new ones
+	if result.notModified() {
+		if etag := res.Header.Get("ETag"); etag != "" {
+			result.Cache.ETag = etag
+		}
+		if lastModified := res.Header.Get("Last-Modified"); lastModified != "" {
+			result.Cache.LastModified = lastModified
+		}
+		return &result, nil
+	}
 
 	// read the response
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return &rss, err
+		return &result, err
 	}
+	result.Bytes = len(body)
 
-	// unmarshal the response into the RSSFeed
-	if err := xml.Unmarshal(body, &rss); err != nil {
-		return &rss, err
+	// parse the response into the RSSFeed
+	if err := parseFeed(body, res.Header.Get("Content-Type"), &rss); err != nil {
+		return &result, err
+	}
+
+	// save the validators for the next fetch
+	result.Cache = feedCache{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
 	}
 
 	rss.Channel.Title = html.UnescapeString(rss.Channel.Title)
@@ -60,5 +191,79 @@ func fetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
 		rss.Channel.Item[i].Title = html.UnescapeString(rss.Channel.Item[i].Title)
 		rss.Channel.Item[i].Description = html.UnescapeString(rss.Channel.Item[i].Description)
 	}
-	return &rss, nil
+	return &result, nil
+}
+
+// parse a feed document into an RSSFeed based on its format
+func parseFeed(body []byte, contentType string, rss *RSSFeed) error {
+	if isJSONFeed(body, contentType) {
+		jsonFeed := JSONFeed{}
+		if err := json.Unmarshal(body, &jsonFeed); err != nil {
+			return err
+		}
+		*rss = jsonFeed.toRSSFeed()
+		return nil
+	}
+
+	root, err := xmlRootElement(body)
+	if err != nil {
+		return err
+	}
+
+	switch root.Local {
+	case "rss":
+		if err := xml.Unmarshal(body, rss); err != nil {
+			return err
+		}
+		// some RSS 2.0 feeds only date their items with dc:date
+		for i, item := range rss.Channel.Item {
+			if item.PubDate == "" {
+				rss.Channel.Item[i].PubDate = item.DCDate
+			}
+		}
+		return nil
+	case "RDF":
+		rdf := RDFFeed{}
+		if err := xml.Unmarshal(body, &rdf); err != nil {
+			return err
+		}
+		*rss = rdf.toRSSFeed()
+		return nil
+	case "feed":
+		atom := AtomFeed{}
+		if err := xml.Unmarshal(body, &atom); err != nil {
+			return err
+		}
+		*rss = atom.toRSSFeed()
+		return nil
+	default:
+		return fmt.Errorf("unsupported feed format: <%s>", root.Local)
+	}
+}
+
+// check the content type, or sniff the body when the type is missing or generic
+func isJSONFeed(body []byte, contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/feed+json" || mediaType == "application/json":
+		return true
+	case strings.HasSuffix(mediaType, "xml"):
+		return false
+	}
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// get the name of the first element in an xml document
+func xmlRootElement(body []byte) (xml.Name, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.Name{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name, nil
+		}
+	}
 }
@@ -0,0 +1,97 @@
+package main
+
+import "testing"
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantTitle   string
+		want        RSSItem
+	}{
+		{
+			name:        "rss 2.0",
+			contentType: "application/rss+xml",
+			body: `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>RSS</title><link>https://example.com/</link>
+<item><title>First</title><link>https://example.com/1</link><guid>1</guid>
+<pubDate>Mon, 02 Jan 2006 15:04:05 +0000</pubDate><author>a@example.com (A)</author></item>
+</channel></rss>`,
+			wantTitle: "RSS",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", GUID: "1", PubDate: "Mon, 02 Jan 2006 15:04:05 +0000", Author: "a@example.com (A)"},
+		},
+		{
+			name:        "rss 2.0 dated with dc:date",
+			contentType: "text/xml",
+			body: `<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/"><channel><title>DC</title>
+<item><title>First</title><link>https://example.com/1</link><dc:date>2006-01-02T15:04:05Z</dc:date><dc:creator>A</dc:creator></item>
+</channel></rss>`,
+			wantTitle: "DC",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", PubDate: "2006-01-02T15:04:05Z", DCDate: "2006-01-02T15:04:05Z", DCCreator: "A"},
+		},
+		{
+			name:        "rss 1.0",
+			contentType: "application/rdf+xml",
+			body: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel><title>RDF</title><link>https://example.com/</link></channel>
+<item rdf:about="https://example.com/1"><title>First</title><link>https://example.com/1</link><dc:date>2006-01-02</dc:date><dc:creator>A</dc:creator></item>
+</rdf:RDF>`,
+			wantTitle: "RDF",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", GUID: "https://example.com/1", PubDate: "2006-01-02", Author: "A"},
+		},
+		{
+			name:        "atom",
+			contentType: "application/atom+xml",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title>
+<entry><id>urn:1</id><title>First</title><link href="https://example.com/1"/><link rel="edit" href="https://example.com/edit/1"/>
+<updated>2006-01-02T15:04:05Z</updated><summary>Sum</summary><author><name>A</name></author><author><name>B</name></author></entry>
+</feed>`,
+			wantTitle: "Atom",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", Description: "Sum", GUID: "urn:1", PubDate: "2006-01-02T15:04:05Z", Author: "A, B"},
+		},
+		{
+			name:        "json feed by content type",
+			contentType: "application/feed+json",
+			body: `{"version": "https://jsonfeed.org/version/1.1", "title": "JSON", "items": [
+{"id": 1, "url": "https://example.com/1", "title": "First", "content_text": "Text", "date_published": "2006-01-02T15:04:05Z", "authors": [{"name": "A"}]}]}`,
+			wantTitle: "JSON",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", Description: "Text", GUID: "1", PubDate: "2006-01-02T15:04:05Z", Author: "A"},
+		},
+		{
+			name:        "json feed sniffed from a generic type",
+			contentType: "text/plain",
+			body: `{"version": "https://jsonfeed.org/version/1", "title": "JSON 1.0", "items": [
+{"id": "a", "url": "https://example.com/1", "title": "First", "date_modified": "2006-01-02T15:04:05Z", "author": {"name": "A"}}]}`,
+			wantTitle: "JSON 1.0",
+			want:      RSSItem{Title: "First", Link: "https://example.com/1", GUID: "a", PubDate: "2006-01-02T15:04:05Z", Author: "A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rss RSSFeed
+			if err := parseFeed([]byte(tt.body), tt.contentType, &rss); err != nil {
+				t.Fatalf("parseFeed: %v", err)
+			}
+			if rss.Channel.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", rss.Channel.Title, tt.wantTitle)
+			}
+			if len(rss.Channel.Item) != 1 {
+				t.Fatalf("got %d items, want 1", len(rss.Channel.Item))
+			}
+			if got := rss.Channel.Item[0]; got != tt.want {
+				t.Errorf("item\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFeedUnsupported(t *testing.T) {
+	for _, body := range []string{`<html><body>not a feed</body></html>`, `not xml at all`} {
+		var rss RSSFeed
+		if err := parseFeed([]byte(body), "text/html", &rss); err == nil {
+			t.Errorf("parseFeed(%q) returned no error", body)
+		}
+	}
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// the address serve listens on when none is given
+	defaultServeAddr = "localhost:8080"
+	// how many posts are returned when no limit is given, and the most that can be asked for
+	defaultAPIPostLimit = 20
+	maxAPIPostLimit     = 100
+)
+
+// an error returned by an http handler, shown to the client with its status
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+func newAPIError(statusCode int, format string, args ...any) *apiError {
+	return &apiError{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+type apiUser struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type apiFeed struct {
+	ID            int32      `json:"id"`
+	Name          string     `json:"name"`
+	URL           string     `json:"url"`
+	SiteURL       string     `json:"site_url,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastFetchedAt *time.Time `json:"last_fetched_at"`
+	Disabled      bool       `json:"disabled"`
+}
+
+type apiFollow struct {
+	FeedID   int32    `json:"feed_id"`
+	FeedName string   `json:"feed_name"`
+	FeedURL  string   `json:"feed_url"`
+	SiteURL  string   `json:"site_url,omitempty"`
+	Folders  []string `json:"folders"`
+}
+
+type apiPost struct {
+	ID          int32      `json:"id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+	PublishedAt *time.Time `json:"published_at"`
+	Author      string     `json:"author,omitempty"`
+	FeedID      int32      `json:"feed_id"`
+	FeedName    string     `json:"feed_name"`
+	Highlighted bool       `json:"highlighted"`
+}
+
+type apiPostPage struct {
+	Posts      []apiPost `json:"posts"`
+	NextOffset *int32    `json:"next_offset"`
+}
+
+// serve the json api until the program is stopped
+func handlerServe(s *state, cmd command) error {
+	addr := defaultServeAddr
+	if len(cmd.args) > 0 {
+		addr = cmd.args[0]
+	}
+
+	fmt.Println("Serving the API on", addr)
+	return listenAndServe(s, addr, newAPIMux(s))
+}
+
+// serve http until the program is stopped, then shut down gracefully
+func listenAndServe(s *state, addr string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-s.ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// register the api routes
+func newAPIMux(s *state) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/me", apiAuth(s, apiGetMe))
+	mux.Handle("GET /api/users", apiAuth(s, apiGetUsers))
+	mux.Handle("GET /api/feeds", apiAuth(s, apiGetFeeds))
+	mux.Handle("POST /api/feeds", apiAuth(s, apiCreateFeed))
+	mux.Handle("GET /api/follows", apiAuth(s, apiGetFollows))
+	mux.Handle("POST /api/follows", apiAuth(s, apiCreateFollow))
+	mux.Handle("DELETE /api/follows/{feedID}", apiAuth(s, apiDeleteFollow))
+	mux.Handle("GET /api/posts", apiAuth(s, apiGetPosts))
+	mux.Handle("/fever", handleFever(s))
+	mux.Handle("/fever/", handleFever(s))
+	registerReaderRoutes(s, mux)
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, newAPIError(http.StatusNotFound, "no such endpoint"))
+	})
+	return mux
+}
+
+// check the bearer token of a request then run the handler with its user
+func apiAuth(s *state, handler func(s *state, w http.ResponseWriter, r *http.Request, user database.User) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeAPIError(w, newAPIError(http.StatusUnauthorized, "missing bearer token"))
+			return
+		}
+
+		user, err := s.db.GetUserByApiToken(r.Context(), database.GetUserByApiTokenParams{
+			LastUsedAt: getNullTimeNow(),
+			TokenHash:  hashAPIToken(token),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			writeAPIError(w, newAPIError(http.StatusUnauthorized, "invalid token"))
+			return
+		}
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		if err := handler(s, w, r, user); err != nil {
+			writeAPIError(w, err)
+		}
+	})
+}
+
+// write a value as json with the given status
+func writeJSON(w http.ResponseWriter, statusCode int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(v)
+}
+
+// write an error as json, errors that are not api errors are logged and hidden from the client
+func writeAPIError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		log.Println("api error:", err)
+		apiErr = newAPIError(http.StatusInternalServerError, "internal server error")
+	}
+	writeJSON(w, apiErr.StatusCode, map[string]string{"error": apiErr.Message})
+}
+
+// decode a json request body into v
+func readJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return newAPIError(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// GET /api/me
+func apiGetMe(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	return writeJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// GET /api/users, the names of all users
+func apiGetUsers(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	names, err := s.db.GetUsers(r.Context())
+	if err != nil {
+		return err
+	}
+	if names == nil {
+		names = []string{}
+	}
+	return writeJSON(w, http.StatusOK, names)
+}
+
+// GET /api/feeds
+func apiGetFeeds(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	feeds, err := s.db.GetFeeds(r.Context())
+	if err != nil {
+		return err
+	}
+
+	list := []apiFeed{}
+	for _, feed := range feeds {
+		list = append(list, toAPIFeed(feed))
+	}
+	return writeJSON(w, http.StatusOK, list)
+}
+
+// POST /api/feeds with {"name": ..., "url": ...}, adds the feed and follows it
+func apiCreateFeed(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	body := struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}{}
+	if err := readJSON(r, &body); err != nil {
+		return err
+	}
+	if body.Name == "" || body.URL == "" {
+		return newAPIError(http.StatusBadRequest, "name and url are required")
+	}
+
+	feedURL := sql.NullString{String: body.URL, Valid: true}
+	if _, err := s.db.GetFeedByUrl(r.Context(), feedURL); err == nil {
+		return newAPIError(http.StatusConflict, "feed %s already exists", body.URL)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	timeNow := getNullTimeNow()
+	if err := s.db.CreateFeed(r.Context(), database.CreateFeedParams{
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+		Name:      sql.NullString{String: body.Name, Valid: true},
+		Url:       feedURL,
+		UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+	}); err != nil {
+		return err
+	}
+	feed, err := s.db.GetFeedByUrl(r.Context(), feedURL)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.CreateFeedFollow(r.Context(), database.CreateFeedFollowParams{
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+		UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+		FeedID:    sql.NullInt32{Int32: feed.ID, Valid: true},
+	}); err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, toAPIFeed(feed))
+}
+
+// GET /api/follows
+func apiGetFollows(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	follows, err := s.db.GetFeedFollowsForUser(r.Context(), user.Name)
+	if err != nil {
+		return err
+	}
+
+	list := []apiFollow{}
+	for _, follow := range follows {
+		list = append(list, apiFollow{
+			FeedID:   follow.FeedID,
+			FeedName: follow.FeedName.String,
+			FeedURL:  follow.FeedUrl.String,
+			SiteURL:  follow.SiteUrl.String,
+			Folders:  follow.Folders,
+		})
+	}
+	return writeJSON(w, http.StatusOK, list)
+}
+
+// POST /api/follows with {"url": ...}
+func apiCreateFollow(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	body := struct {
+		URL string `json:"url"`
+	}{}
+	if err := readJSON(r, &body); err != nil {
+		return err
+	}
+	if body.URL == "" {
+		return newAPIError(http.StatusBadRequest, "url is required")
+	}
+
+	feed, err := s.db.GetFeedByUrl(r.Context(), sql.NullString{String: body.URL, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return newAPIError(http.StatusNotFound, "no feed with url %s", body.URL)
+	}
+	if err != nil {
+		return err
+	}
+
+	// following a feed twice is not an error
+	userID := uuid.NullUUID{UUID: user.ID, Valid: true}
+	feedID := sql.NullInt32{Int32: feed.ID, Valid: true}
+	_, err = s.db.GetFeedFollow(r.Context(), database.GetFeedFollowParams{UserID: userID, FeedID: feedID})
+	if err == nil {
+		return writeJSON(w, http.StatusOK, toAPIFeed(feed))
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	timeNow := getNullTimeNow()
+	if _, err := s.db.CreateFeedFollow(r.Context(), database.CreateFeedFollowParams{
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+		UserID:    userID,
+		FeedID:    feedID,
+	}); err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, toAPIFeed(feed))
+}
+
+// DELETE /api/follows/{feedID}
+func apiDeleteFollow(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	feedID, err := strconv.ParseInt(r.PathValue("feedID"), 10, 32)
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "invalid feed id %q", r.PathValue("feedID"))
+	}
+
+	if err := s.db.DeleteFeedFollow(r.Context(), database.DeleteFeedFollowParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		FeedID: sql.NullInt32{Int32: int32(feedID), Valid: true},
+	}); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// GET /api/posts?limit=n&offset=n, newest first
+func apiGetPosts(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	limit, err := queryInt32(r, "limit", defaultAPIPostLimit)
+	if err != nil {
+		return err
+	}
+	if limit < 1 || limit > maxAPIPostLimit {
+		return newAPIError(http.StatusBadRequest, "limit must be between 1 and %d", maxAPIPostLimit)
+	}
+	offset, err := queryInt32(r, "offset", 0)
+	if err != nil {
+		return err
+	}
+	if offset < 0 {
+		return newAPIError(http.StatusBadRequest, "offset must not be negative")
+	}
+
+	posts, err := s.db.GetPostsForUser(r.Context(), database.GetPostsForUserParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	page := apiPostPage{Posts: []apiPost{}}
+	for _, post := range posts {
+		page.Posts = append(page.Posts, apiPost{
+			ID:          post.ID,
+			Title:       post.Title,
+			URL:         post.Url,
+			Description: post.Description.String,
+			PublishedAt: nullTimePtr(post.PublishedAt),
+			Author:      post.Author.String,
+			FeedID:      post.FeedID,
+			FeedName:    post.FeedName.String,
+			Highlighted: post.IsHighlighted,
+		})
+	}
+
+	// a full page means there may be more
+	if int32(len(posts)) == limit {
+		next := offset + limit
+		page.NextOffset = &next
+	}
+	return writeJSON(w, http.StatusOK, page)
+}
+
+// read an integer query parameter, returning def when it is not set
+func queryInt32(r *http.Request, name string, def int32) (int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, newAPIError(http.StatusBadRequest, "invalid %s %q", name, value)
+	}
+	return int32(n), nil
+}
+
+func toAPIUser(user database.User) apiUser {
+	return apiUser{ID: user.ID, Name: user.Name, CreatedAt: user.CreatedAt.Time}
+}
+
+func toAPIFeed(feed database.Feed) apiFeed {
+	return apiFeed{
+		ID:            feed.ID,
+		Name:          feed.Name.String,
+		URL:           feed.Url.String,
+		SiteURL:       feed.SiteUrl.String,
+		CreatedAt:     feed.CreatedAt.Time,
+		LastFetchedAt: nullTimePtr(feed.LastFetchedAt),
+		Disabled:      feed.DisabledAt.Valid,
+	}
+}
+
+// null times are written as json null
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// create, list and revoke the api tokens of the logged in user
+func handlerToken(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("token command requires create, list or revoke")
+	}
+
+	switch cmd.args[0] {
+	case "create":
+		if len(cmd.args) < 2 {
+			return fmt.Errorf("token create requires a name")
+		}
+		token, err := newAPIToken()
+		if err != nil {
+			return err
+		}
+		apiToken, err := s.db.CreateApiToken(s.ctx, database.CreateApiTokenParams{
+			CreatedAt: time.Now(),
+			UserID:    user.ID,
+			Name:      cmd.args[1],
+			TokenHash: hashAPIToken(token),
+			FeverKey:  sql.NullString{String: feverKey(user.Name, token), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		// only the hash is stored, so the token can not be shown again
+		fmt.Printf("Created token %d (%s) for %s:\n", apiToken.ID, apiToken.Name, user.Name)
+		fmt.Println(token)
+		fmt.Println("Store it now, it will not be shown again")
+
+	case "list":
+		tokens, err := s.db.GetApiTokensForUser(s.ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			lastUsed := "never"
+			if token.LastUsedAt.Valid {
+				lastUsed = token.LastUsedAt.Time.String()
+			}
+			fmt.Printf("%d: %s, created %s, last used %s\n", token.ID, token.Name, token.CreatedAt.String(), lastUsed)
+		}
+
+	case "revoke":
+		if len(cmd.args) < 2 {
+			return fmt.Errorf("token revoke requires a token id")
+		}
+		id, err := strconv.ParseInt(cmd.args[1], 10, 32)
+		if err != nil {
+			return err
+		}
+		deleted, err := s.db.DeleteApiToken(s.ctx, database.DeleteApiTokenParams{ID: int32(id), UserID: user.ID})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return fmt.Errorf("%s has no token %d", user.Name, id)
+		}
+		fmt.Println("Revoked token", id)
+
+	default:
+		return fmt.Errorf("unknown token command %q, use create, list or revoke", cmd.args[0])
+	}
+	return nil
+}
+
+// generate a random api token
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// tokens are stored as their sha256 hash
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
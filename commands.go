@@ -3,19 +3,28 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"gator/internal/config"
 	"gator/internal/database"
+	"io"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type state struct {
+	ctx context.Context // cancelled when the program is asked to stop
 	db  *database.Queries
 	cfg *config.Config
+	// set by commands that write a document to stdout, so nothing is printed after it
+	wroteDocument bool
 }
 
 type command struct {
@@ -34,7 +43,7 @@ func handlerLogin(s *state, cmd command) error {
 	}
 
 	//check if user exists in database
-	if _, err := s.db.GetUserByName(context.Background(), cmd.args[0]); err != nil {
+	if _, err := s.db.GetUserByName(s.ctx, cmd.args[0]); err != nil {
 		return err
 	}
 
@@ -91,10 +100,10 @@ func handlerRegister(s *state, cmd command) error {
 	}
 
 	// perform the sql insertion
-	s.db.CreateUser(context.Background(), userParams)
+	s.db.CreateUser(s.ctx, userParams)
 
 	// get the user to verify addition to table
-	_, err := s.db.GetUser(context.Background(), userId.UUID)
+	_, err := s.db.GetUser(s.ctx, userId.UUID)
 	if err != nil {
 		fmt.Println("User already exists:", err)
 		os.Exit(1)
@@ -113,12 +122,12 @@ func handlerRegister(s *state, cmd command) error {
 // reset the database
 func handlerReset(s *state, cmd command) error {
 	// reset users table
-	if err := s.db.Generate(context.Background()); err != nil {
+	if err := s.db.Generate(s.ctx); err != nil {
 		return err
 	}
 
 	// reset feed table
-	if err := s.db.ResetFeed(context.Background()); err != nil {
+	if err := s.db.ResetFeed(s.ctx); err != nil {
 		return err
 	}
 
@@ -130,7 +139,7 @@ func handlerReset(s *state, cmd command) error {
 // get a list of users
 func handlerUsers(s *state, cmd command) error {
 	// get the list
-	userList, err := s.db.GetUsers(context.Background())
+	userList, err := s.db.GetUsers(s.ctx)
 	if err != nil {
 		return err
 	}
@@ -148,24 +157,100 @@ func handlerUsers(s *state, cmd command) error {
 
 // Aggregate posts from feeds
 func handlerAgg(s *state, cmd command) error {
-	if len(cmd.args) < 1 {
+	fs := flag.NewFlagSet("agg", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "remove the posts over the retention limits after each cycle")
+	args, err := parseArgs(fs, cmd.args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
 		return fmt.Errorf("agg command requires a time duration")
 	}
 
 	// get the wait time (argument)
-	waitTime, err := time.ParseDuration(cmd.args[0])
+	waitTime, err := time.ParseDuration(args[0])
 	if err != nil {
 		return err
 	}
 
+	// get the number of workers (optional argument)
+	workers := 1
+	if len(args) > 1 {
+		workers, err = strconv.Atoi(args[1])
+		if err != nil || workers < 1 {
+			return fmt.Errorf("agg concurrency must be a positive number")
+		}
+	}
+
 	// create a ticker to wait for the given duration
 	ticker := time.NewTicker(waitTime)
-	for ; ; <-ticker.C {
-		// scrape the feeds
-		scrapeFeeds(s)
+	defer ticker.Stop()
+	for {
+		// scrape every feed that is due, feeds are fetched at most once per wait time
+		scrapeDueFeeds(s, workers, waitTime)
+
+		// a failed prune is retried on the next cycle
+		if *prune && s.ctx.Err() == nil {
+			if err := prunePosts(s, false); err != nil {
+				fmt.Println("Error pruning posts:", err)
+			}
+		}
+
+		// wait for the next tick or stop when interrupted
+		select {
+		case <-s.ctx.Done():
+			fmt.Println("Aggregation stopped")
+			return nil
+		case <-ticker.C:
+		}
 	}
 }
 
+// set how often a feed is fetched, or let agg work it out
+func handlerInterval(s *state, cmd command) error {
+	if len(cmd.args) < 2 {
+		return fmt.Errorf("interval command requires a url and a time duration or auto")
+	}
+
+	// get the feed from the url
+	feed, err := s.db.GetFeedByUrl(s.ctx, sql.NullString{String: cmd.args[0], Valid: true})
+	if err != nil {
+		return err
+	}
+
+	// a null interval lets agg schedule the feed from its hints and posting frequency
+	interval := sql.NullInt32{}
+	if cmd.args[1] != "auto" {
+		duration, err := time.ParseDuration(cmd.args[1])
+		if err != nil {
+			return err
+		}
+		if duration < time.Second {
+			return fmt.Errorf("interval must be at least one second")
+		}
+		if duration.Seconds() > float64(1<<31-1) {
+			return fmt.Errorf("interval %q is out of range", cmd.args[1])
+		}
+		interval = sql.NullInt32{Int32: int32(duration.Seconds()), Valid: true}
+	}
+
+	// save the interval, the feed is fetched on the next agg tick
+	if err := s.db.SetFeedFetchInterval(s.ctx, database.SetFeedFetchIntervalParams{
+		UpdatedAt:            getNullTimeNow(),
+		FetchIntervalSeconds: interval,
+		ID:                   feed.ID,
+	}); err != nil {
+		return err
+	}
+
+	if interval.Valid {
+		fmt.Printf("%s will be fetched every %s\n", feed.Name.String, time.Duration(interval.Int32)*time.Second)
+	} else {
+		fmt.Printf("%s will be fetched on an automatic schedule\n", feed.Name.String)
+	}
+	return nil
+}
+
 // add a feed to the feed table
 func handlerAddFeed(s *state, cmd command, user database.User) error {
 	if len(cmd.args) < 2 {
@@ -193,7 +278,7 @@ func handlerAddFeed(s *state, cmd command, user database.User) error {
 	}
 
 	// create the feed
-	if err := s.db.CreateFeed(context.Background(), database.CreateFeedParams{
+	if err := s.db.CreateFeed(s.ctx, database.CreateFeedParams{
 		CreatedAt: timeNow,
 		UpdatedAt: timeNow,
 		Name:      feedName,
@@ -216,7 +301,7 @@ func handlerAddFeed(s *state, cmd command, user database.User) error {
 
 // print the values of a feed
 func printFeed(s *state, nameString string) error {
-	feed, err := s.db.GetFeed(context.Background(), sql.NullString{String: nameString, Valid: true})
+	feed, err := s.db.GetFeed(s.ctx, sql.NullString{String: nameString, Valid: true})
 	if err != nil {
 		return err
 	}
@@ -231,17 +316,26 @@ func printFeed(s *state, nameString string) error {
 
 }
 
+// get a feed by its url, falling back to its name
+func getFeedByUrlOrName(s *state, urlOrName string) (database.Feed, error) {
+	feed, err := s.db.GetFeedByUrl(s.ctx, sql.NullString{String: urlOrName, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.db.GetFeed(s.ctx, sql.NullString{String: urlOrName, Valid: true})
+	}
+	return feed, err
+}
+
 // print a list of feeds
 func handlerFeeds(s *state, cmd command) error {
 	// get the list
-	feeds, err := s.db.GetFeeds(context.Background())
+	feeds, err := s.db.GetFeeds(s.ctx)
 	if err != nil {
 		return err
 	}
 
 	// print the list
 	for _, feed := range feeds {
-		poster, err := s.db.GetUser(context.Background(), feed.UserID.UUID)
+		poster, err := s.db.GetUser(s.ctx, feed.UserID.UUID)
 		if err != nil {
 			return err
 		}
@@ -260,7 +354,7 @@ func handlerFollow(s *state, cmd command, user database.User) error {
 	}
 
 	// Get the requested feed
-	feed, err := s.db.GetFeedByUrl(context.Background(), sql.NullString{String: cmd.args[0], Valid: true})
+	feed, err := s.db.GetFeedByUrl(s.ctx, sql.NullString{String: cmd.args[0], Valid: true})
 	if err != nil {
 		return err
 	}
@@ -277,7 +371,7 @@ func handlerFollow(s *state, cmd command, user database.User) error {
 	}
 
 	// create the feed_follow entry
-	newFeedFollow, err := s.db.CreateFeedFollow(context.Background(), Params)
+	newFeedFollow, err := s.db.CreateFeedFollow(s.ctx, Params)
 	if err != nil {
 		return err
 	}
@@ -288,26 +382,66 @@ func handlerFollow(s *state, cmd command, user database.User) error {
 	return nil
 }
 
-// get a list of the feeds that the user is following
+// get a list of the feeds that the user is following, grouped by folder with --tree
 func handlerFollowing(s *state, cmd command, user database.User) error {
+	fs := flag.NewFlagSet("following", flag.ContinueOnError)
+	tree := fs.Bool("tree", false, "group the feeds by folder")
+	if _, err := parseArgs(fs, cmd.args); err != nil {
+		return err
+	}
+
 	// get the list
-	follows, err := s.db.GetFeedFollowsForUser(context.Background(), user.Name)
+	follows, err := s.db.GetFeedFollowsForUser(s.ctx, user.Name)
 	if err != nil {
 		return err
 	}
 
+	// get the number of unread posts of each feed
+	counts, err := s.db.GetUnreadCounts(s.ctx, uuid.NullUUID{UUID: user.ID, Valid: true})
+	if err != nil {
+		return err
+	}
+	unread := map[int32]int64{}
+	for _, count := range counts {
+		unread[count.FeedID] = count.Unread
+	}
+
 	// print the list
 	fmt.Printf("User %s Following:\n", user.Name)
+	if !*tree {
+		for _, follow := range follows {
+			fmt.Printf("%s (%d unread)\n", follow.FeedName.String, unread[follow.FeedID])
+		}
+		return nil
+	}
+
+	// empty folders are shown too, feeds in no folder come last
+	folders, err := s.db.GetFoldersForUser(s.ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	root := &folderNode{children: map[string]*folderNode{}}
+	for _, folder := range folders {
+		root.child(folder.Name)
+	}
 	for _, follow := range follows {
-		fmt.Println(follow.FeedName.String)
+		line := fmt.Sprintf("%s (%d unread)", follow.FeedName.String, unread[follow.FeedID])
+		if len(follow.Folders) == 0 {
+			root.feeds = append(root.feeds, line)
+		}
+		for _, folder := range follow.Folders {
+			node := root.child(folder)
+			node.feeds = append(node.feeds, line)
+		}
 	}
+	root.print(0)
 	return nil
 }
 
 // unfollow a given feed
 func handlerUnfollow(s *state, cmd command, user database.User) error {
 	// get feed from url
-	feed, err := s.db.GetFeedByUrl(context.Background(), sql.NullString{String: cmd.args[0], Valid: true})
+	feed, err := s.db.GetFeedByUrl(s.ctx, sql.NullString{String: cmd.args[0], Valid: true})
 	if err != nil {
 		return err
 	}
@@ -319,7 +453,7 @@ func handlerUnfollow(s *state, cmd command, user database.User) error {
 	}
 
 	// delete the feed_follow entry
-	if err := s.db.DeleteFeedFollow(context.Background(), Params); err != nil {
+	if err := s.db.DeleteFeedFollow(s.ctx, Params); err != nil {
 		return err
 	}
 	return nil
@@ -328,7 +462,7 @@ func handlerUnfollow(s *state, cmd command, user database.User) error {
 // check if a user is logged in then run the function with that user as a parameter
 func middlewareLoggedIn(handler func(s *state, cmd command, user database.User) error) func(*state, command) error {
 	return func(s *state, cmd command) error {
-		user, err := s.db.GetUserByName(context.Background(), s.cfg.CurrentUserName)
+		user, err := s.db.GetUserByName(s.ctx, s.cfg.CurrentUserName)
 		if err != nil {
 			return err
 		}
@@ -336,68 +470,251 @@ func middlewareLoggedIn(handler func(s *state, cmd command, user database.User)
 	}
 }
 
-// scrape the oldest feed
-func scrapeFeeds(s *state) error {
-	feed, err := s.db.GetNextFeedToFetch(context.Background())
+// scrape every due feed with a pool of workers
+func scrapeDueFeeds(s *state, workers int, defaultInterval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// keep claiming feeds until none are due or the program is stopping
+			for s.ctx.Err() == nil {
+				scraped, err := scrapeFeeds(s, defaultInterval)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					fmt.Println("Error scraping feed:", err)
+				}
+				if !scraped {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// claim and scrape the next due feed, returns false if no feed was due
+func scrapeFeeds(s *state, defaultInterval time.Duration) (bool, error) {
+	// claim the feed so other workers and agg processes skip it until the lease runs out
+	timeNow := time.Now()
+	feed, err := s.db.GetNextFeedToFetch(s.ctx, database.GetNextFeedToFetchParams{
+		Now:        sql.NullTime{Time: timeNow, Valid: true},
+		LeaseUntil: sql.NullTime{Time: timeNow.Add(claimLease), Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
-		return err
+		return false, err
 	}
+	return true, scrapeFeed(s, feed, defaultInterval)
+}
 
+// fetch a feed and save its items to the posts table
+func scrapeFeed(s *state, feed database.Feed, defaultInterval time.Duration) (err error) {
 	// get current time
 	timeNow := getNullTimeNow()
 
-	// set parameters for marking the feed as fetched
-	params := database.MarkFeedFetchedParams{
-		UpdatedAt:     timeNow,
-		LastFetchedAt: timeNow,
-		ID:            feed.ID,
+	// record the attempt in the fetch log however it ends
+	entry := fetchLogEntry{startedAt: timeNow.Time}
+	defer func() {
+		entry.err = err
+		if logErr := writeFetchLog(s, feed, entry); logErr != nil {
+			fmt.Println("Error writing fetch log:", logErr)
+		}
+	}()
+
+	// fetch the feed, sending the cache validators from the last fetch
+	cache := feedCache{ETag: feed.Etag.String, LastModified: feed.LastModified.String}
+	hint := feed.UpdateHintSeconds
+	siteUrl := feed.SiteUrl
+	result, fetchErr := fetchFeed(s.ctx, feed.Url.String, cache)
+	entry.statusCode = result.StatusCode
+	entry.bytes = result.Bytes
+
+	// items are only saved when it is known which of them prune would remove again
+	var cutoff time.Time
+	if fetchErr == nil {
+		cutoff, fetchErr = retentionCutoff(s, feed, timeNow.Time)
+	}
+	if fetchErr == nil {
+		cache = result.Cache
+		entry.itemsSeen, entry.itemsNew, entry.itemErr = savePosts(s, feed, result, timeNow, cutoff)
+		if !result.notModified() {
+			hint = getNullInt32(int32(result.Feed.updateHint().Seconds()))
+			if result.Feed.Channel.Link != "" {
+				siteUrl = getNullString(result.Feed.Channel.Link)
+			}
+		}
 	}
 
-	// mark the feed as fetched
-	if err := s.db.MarkFeedFetched(context.Background(), params); err != nil {
-		return err
+	// the fetch was cut short by a shutdown, make the feed due again for the next run
+	if s.ctx.Err() != nil {
+		if err := s.db.ReleaseFeed(context.WithoutCancel(s.ctx), feed.ID); err != nil {
+			return err
+		}
+		return s.ctx.Err()
 	}
 
-	// fetch the feed
-	RSS, err := fetchFeed(context.Background(), feed.Url.String)
+	// work out when the feed should be fetched next
+	nextFetch, err := nextFetchTime(s, feed, time.Duration(hint.Int32)*time.Second, defaultInterval)
 	if err != nil {
 		return err
 	}
 
+	// a failed fetch counts against the feed's health and is retried with backoff
+	if fetchErr != nil {
+		if err := recordFeedFailure(s, feed, fetchErr, nextFetch); err != nil {
+			return err
+		}
+		return fetchErr
+	}
+
+	// set parameters for marking the feed as fetched
+	params := database.MarkFeedFetchedParams{
+		UpdatedAt:         timeNow,
+		LastFetchedAt:     timeNow,
+		Etag:              getNullString(cache.ETag),
+		LastModified:      getNullString(cache.LastModified),
+		NextFetchAt:       sql.NullTime{Time: nextFetch, Valid: true},
+		UpdateHintSeconds: hint,
+		SiteUrl:           siteUrl,
+		ID:                feed.ID,
+	}
+
+	// mark the feed as fetched successfully
+	if err := s.db.MarkFeedFetched(s.ctx, params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// save the items of a fetched feed and follow permanent redirects,
+// returns the number of items seen and new, and the first item error
+func savePosts(s *state, feed database.Feed, result *fetchResult, timeNow sql.NullTime, cutoff time.Time) (int, int, error) {
+	// the feed moved permanently, store the new url and keep the old one for lookups
+	if result.MovedTo != "" && result.MovedTo != feed.Url.String {
+		if err := s.db.UpdateFeedUrl(s.ctx, database.UpdateFeedUrlParams{
+			UpdatedAt: timeNow,
+			Url:       getNullString(result.MovedTo),
+			ID:        feed.ID,
+		}); err != nil {
+			fmt.Printf("%s moved to %s but the url could not be updated: %v\n", feed.Name.String, result.MovedTo, err)
+		} else {
+			fmt.Printf("%s moved to %s\n", feed.Name.String, result.MovedTo)
+		}
+	}
+
+	// the feed has not changed since the last fetch
+	if result.notModified() {
+		fmt.Println(feed.Name.String, "(not modified)")
+		return 0, 0, nil
+	}
+	RSS := result.Feed
+
 	// print the feed
 	fmt.Println(RSS.Channel.Title)
+	seen, added := 0, 0
+	var itemErr error
 	for _, item := range RSS.Channel.Item {
-		// attempt to parse the publish time of the feed
-		publishedAt, err := parseTime(item.PubDate)
-		if err != nil {
-			// fmt.Println("failed to parse time")
-			return err
+		// stop between items when the program is stopping
+		if s.ctx.Err() != nil {
+			break
 		}
 
-		// set a description value for sql insertion
-		desc := sql.NullString{}
-		if item.Description != "" {
-			desc = sql.NullString{String: item.Description, Valid: true}
+		// save each item on its own so one bad item doesn't stop the rest
+		seen++
+		status, err := savePost(s, feed.ID, item, timeNow, cutoff)
+		if err != nil {
+			fmt.Printf(" ! %s: %v\n", item.Title, err)
+			if itemErr == nil {
+				itemErr = fmt.Errorf("%s: %w", item.Title, err)
+			}
+			continue
 		}
 
-		// set up parameters for creating a post in the posts table
-		params := database.CreatePostParams{
-			CreatedAt:   timeNow,
-			UpdatedAt:   timeNow,
-			Title:       item.Title,
-			Url:         item.Link,
-			Description: desc,
-			PublishedAt: sql.NullTime{Time: publishedAt, Valid: true},
-			FeedID:      feed.ID,
+		switch status {
+		case postNew:
+			added++
+			fmt.Println(" -", item.Title)
+		case postUpdated:
+			fmt.Println(" ~", item.Title)
+		case postDropped:
+			fmt.Println(" x", item.Title)
 		}
+	}
+	return seen, added, itemErr
+}
 
-		// add the post to the posts table
-		if err := s.db.CreatePost(context.Background(), params); err != nil {
-			return err
-		}
-		fmt.Println(" -", item.Title)
+// what happened to a feed item when it was saved
+type postStatus int
+
+const (
+	postUnchanged postStatus = iota
+	postNew
+	postUpdated
+	postDropped
+)
+
+// insert a feed item into the posts table, or update it if the publisher edited it.
+// Items published before the cutoff are skipped, prune would remove them again
+func savePost(s *state, feedID int32, item RSSItem, timeNow sql.NullTime, cutoff time.Time) (postStatus, error) {
+	// identify the item by its guid, fall back to its link
+	guid := item.GUID
+	if guid == "" {
+		guid = item.Link
 	}
-	return nil
+	if guid == "" {
+		return postUnchanged, fmt.Errorf("item has no guid or link")
+	}
+
+	// attempt to parse the publish time, use the time it was first seen if that fails
+	publishedAt, err := parseTime(item.PubDate)
+	if err != nil {
+		publishedAt = timeNow.Time
+	}
+	if publishedAt.Before(cutoff) {
+		return postUnchanged, nil
+	}
+
+	// RSS 2.0 authors are email addresses, many feeds use dc:creator instead
+	author := item.DCCreator
+	if author == "" {
+		author = item.Author
+	}
+
+	// set up parameters for upserting a post in the posts table
+	params := database.UpsertPostParams{
+		CreatedAt:   timeNow,
+		UpdatedAt:   timeNow,
+		Title:       item.Title,
+		Url:         item.Link,
+		Description: getNullString(item.Description),
+		PublishedAt: sql.NullTime{Time: publishedAt, Valid: true},
+		FeedID:      feedID,
+		Guid:        guid,
+		Author:      getNullString(author),
+	}
+
+	// new items every follower of the feed has a drop rule for are not saved, posts
+	// already saved are updated as usual. Posts saved before authors were stored
+	// have none, getting one is not counted as an edit
+	post, err := s.db.UpsertPost(s.ctx, params)
+	if err != nil {
+		return postUnchanged, err
+	}
+	switch {
+	// pruned posts are not saved again
+	case post.Pruned:
+		return postUnchanged, nil
+	case post.Dropped:
+		return postDropped, nil
+	// no post is returned when it already exists unchanged
+	case !post.ID.Valid:
+		return postUnchanged, nil
+	case post.Inserted.Bool:
+		return postNew, nil
+	}
+	return postUpdated, nil
 }
 
 // get a nulltime struct for the current time for sql insertion
@@ -408,11 +725,29 @@ func getNullTimeNow() sql.NullTime {
 	}
 }
 
+// get a nullstring struct for sql insertion, empty strings are stored as null
+func getNullString(str string) sql.NullString {
+	return sql.NullString{
+		String: str,
+		Valid:  str != "",
+	}
+}
+
+// get a nullint32 struct for sql insertion, zero is stored as null
+func getNullInt32(n int32) sql.NullInt32 {
+	return sql.NullInt32{
+		Int32: n,
+		Valid: n != 0,
+	}
+}
+
 // parse a time stamp string
 func parseTime(timeStamp string) (time.Time, error) {
 	// list of layouts to try parsing
+	// W3C date formats are used by Dublin Core dc:date elements
 	layouts := []string{time.ANSIC, time.UnixDate, time.RubyDate, time.RFC822, time.RFC822Z,
-		time.RFC850, time.RFC1123, time.RFC1123Z, time.RFC3339, time.RFC3339Nano}
+		time.RFC850, time.RFC1123, time.RFC1123Z, time.RFC3339, time.RFC3339Nano,
+		"2006-01-02T15:04Z07:00", "2006-01-02", "2006-01", "2006"}
 
 	// try parsing
 	for _, layout := range layouts {
@@ -424,43 +759,219 @@ func parseTime(timeStamp string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to parse time stamp")
 }
 
-// print a number of posts
+// print a number of posts, a page at a time with --before and --after
 func handlerBrowse(s *state, cmd command, user database.User) error {
+	fs := flag.NewFlagSet("browse", flag.ContinueOnError)
+	unread := fs.Bool("unread", false, "only show unread posts")
+	folderName := fs.String("folder", "", "only show posts of the feeds in a folder and its subfolders")
+	hidden := fs.Bool("hidden", false, "also show posts hidden by filter rules")
+	feedName := fs.String("feed", "", "only show posts of a feed, by url or name")
+	since := fs.String("since", "", "only show posts published since a time ago, like 24h or 7d, or since a date")
+	before := fs.Int("before", 0, "only show posts published before the post with this id")
+	after := fs.Int("after", 0, "only show posts published after the post with this id")
+	offset := fs.Int("offset", 0, "skip this many posts")
+	order := fs.String("order", "desc", "desc for the newest posts first, asc for the oldest first")
+	args, err := parseArgs(fs, cmd.args)
+	if err != nil {
+		return err
+	}
+
 	// default value to print is 2
 	limitParam := int32(2)
 
 	// set the number to print if a number is given
-	if len(cmd.args) > 0 {
-		limit, err := strconv.ParseInt(cmd.args[0], 10, 32)
+	if len(args) > 0 {
+		limit, err := strconv.ParseInt(args[0], 10, 32)
 		if err != nil {
 			return err
 		}
 		limitParam = int32(limit)
 	}
+	if *offset < 0 {
+		return fmt.Errorf("offset must not be negative")
+	}
+	if *order != "asc" && *order != "desc" {
+		return fmt.Errorf("order must be asc or desc")
+	}
 
 	// set up parameters for sql request
 	params := database.GetPostsForUserParams{
-		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
-		Limit:  limitParam,
+		UserID:     uuid.NullUUID{UUID: user.ID, Valid: true},
+		UnreadOnly: *unread,
+		ShowHidden: *hidden,
+		Limit:      limitParam,
+		Offset:     int32(*offset),
+	}
+	if *folderName != "" {
+		folder, err := cleanFolderName(*folderName)
+		if err != nil {
+			return err
+		}
+		params.Folder = sql.NullString{String: folder, Valid: true}
+	}
+	if *feedName != "" {
+		feed, err := getFeedByUrlOrName(s, *feedName)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("there is no feed %s", *feedName)
+		}
+		if err != nil {
+			return err
+		}
+		params.FeedID = sql.NullInt32{Int32: feed.ID, Valid: true}
+	}
+	if *since != "" {
+		sinceTime, err := parseSince(*since, time.Now())
+		if err != nil {
+			return err
+		}
+		params.Since = sql.NullTime{Time: sinceTime, Valid: true}
 	}
 
-	// get the posts
-	posts, err := s.db.GetPostsForUser(context.Background(), params)
-	if err != nil {
-		return err
+	// pages are keyed on the publish time and id of a post, so they stay
+	// the same when new posts come in
+	if *before != 0 {
+		if params.BeforePublishedAt, err = browseCursor(s, user, *before); err != nil {
+			return err
+		}
+		params.BeforeID = sql.NullInt32{Int32: int32(*before), Valid: true}
+	}
+	if *after != 0 {
+		if params.AfterPublishedAt, err = browseCursor(s, user, *after); err != nil {
+			return err
+		}
+		params.AfterID = sql.NullInt32{Int32: int32(*after), Valid: true}
+	}
+
+	// a page going the other way from the order is read nearest the cursor first, then turned around
+	oldestFirst := *order == "asc"
+	reverse := (*after != 0 && *before == 0 && !oldestFirst) || (*before != 0 && *after == 0 && oldestFirst)
+	if reverse {
+		oldestFirst = !oldestFirst
+	}
+
+	// get the posts, each order has its own query so it can use the publish time index
+	var posts []database.GetPostsForUserRow
+	if oldestFirst {
+		rows, err := s.db.GetPostsForUserAsc(s.ctx, database.GetPostsForUserAscParams(params))
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			posts = append(posts, database.GetPostsForUserRow(row))
+		}
+	} else {
+		posts, err = s.db.GetPostsForUser(s.ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+	if reverse {
+		slices.Reverse(posts)
 	}
 
 	// print the posts
 	printPosts(posts)
+
+	// a full page means there may be more, past the last post or, on a
+	// reversed page, past the first one
+	if len(posts) > 0 && int32(len(posts)) == limitParam {
+		newer := *order == "asc"
+		next := posts[len(posts)-1]
+		if reverse {
+			newer = !newer
+			next = posts[0]
+		}
+		cursor := "--before"
+		if newer {
+			cursor = "--after"
+		}
+		fmt.Printf("More posts with %s %d\n", cursor, next.ID)
+	}
 	return nil
 }
 
+// get the publish time of the post a page starts from
+func browseCursor(s *state, user database.User, postID int) (sql.NullTime, error) {
+	post, err := s.db.GetPostForUser(s.ctx, database.GetPostForUserParams{
+		ID:     int32(postID),
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.NullTime{}, fmt.Errorf("there is no post %d", postID)
+	}
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	if !post.PublishedAt.Valid {
+		return sql.NullTime{}, fmt.Errorf("post %d has no publish time", postID)
+	}
+	return post.PublishedAt, nil
+}
+
+// parse --since as a time ago, like 24h or 7d, or as a date or time stamp
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if age, err := parseDuration(value); err == nil {
+		return now.Add(-age), nil
+	}
+	since, err := parseTime(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since %q, use a time ago like 24h or 7d, or a date", value)
+	}
+	return since, nil
+}
+
+// parse a duration, which may also be given in days like 7d
+func parseDuration(value string) (time.Duration, error) {
+	days, ok := strings.CutSuffix(value, "d")
+	if !ok {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return duration, nil
+	}
+	n, err := strconv.Atoi(days)
+	if err != nil || n < 0 || n > 100000 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(n) * 24 * time.Hour, nil
+}
+
 // print a slice of posts
 func printPosts(posts []database.GetPostsForUserRow) {
 	for i, post := range posts {
-		fmt.Println("-- Post", i+1)
+		status := ""
+		if !post.IsRead {
+			status += ", unread"
+		}
+		if post.IsHighlighted {
+			status += ", highlighted"
+		}
+		fmt.Printf("-- Post %d (id %d%s)\n", i+1, post.ID, status)
 		fmt.Println(post.Title)
 		fmt.Println(post.PublishedAt.Time)
 		fmt.Println(post.Description.String)
 	}
 }
+
+// parse flags given before, between or after the positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%s: %w", fs.Name(), err)
+		}
+		rest := fs.Args()
+		// everything after -- is positional
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		args = rest
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		want       []string
+		wantUnread bool
+		wantFeed   string
+	}{
+		{"no args", nil, nil, false, ""},
+		{"positional only", []string{"5"}, []string{"5"}, false, ""},
+		{"flags before", []string{"--unread", "--feed", "blog", "5"}, []string{"5"}, true, "blog"},
+		{"flags after", []string{"5", "--unread"}, []string{"5"}, true, ""},
+		{"flags between", []string{"a", "-feed=blog", "b"}, []string{"a", "b"}, false, "blog"},
+		{"double dash ends flags", []string{"--", "--unread"}, []string{"--unread"}, false, ""},
+		{"double dash ends all flags", []string{"--", "a", "--unread", "-feed=blog"}, []string{"a", "--unread", "-feed=blog"}, false, ""},
+		{"double dash after flags", []string{"--feed", "blog", "a", "--", "b", "--unread"}, []string{"a", "b", "--unread"}, false, "blog"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("browse", flag.ContinueOnError)
+			unread := fs.Bool("unread", false, "")
+			feed := fs.String("feed", "", "")
+			got, err := parseArgs(fs, tt.args)
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+			if !slices.Equal(got, tt.want) || *unread != tt.wantUnread || *feed != tt.wantFeed {
+				t.Errorf("parseArgs(%q) = %q, unread %v, feed %q; want %q, %v, %q", tt.args, got, *unread, *feed, tt.want, tt.wantUnread, tt.wantFeed)
+			}
+		})
+	}
+}
+
+func TestParseArgsUnknownFlag(t *testing.T) {
+	fs := flag.NewFlagSet("browse", flag.ContinueOnError)
+	if _, err := parseArgs(fs, []string{"--nope"}); err == nil {
+		t.Error("parseArgs accepted an unknown flag")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"0d", 0, false},
+		{"100000d", 100000 * 24 * time.Hour, false},
+		{"100001d", 0, true},
+		{"-1d", 0, true},
+		{"-24h", 0, true},
+		{"1.5d", 0, true},
+		{"d", 0, true},
+		{"week", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"24h", now.Add(-24 * time.Hour), false},
+		{"7d", now.AddDate(0, 0, -7), false},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), false},
+		{"2023", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"yesterday", time.Time{}, true},
+		{"-1d", time.Time{}, true},
+		{"-24h", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gator/internal/database"
+)
+
+// how many fetch log entries are shown
+const defaultFetchLogLimit = 20
+
+// what happened during one attempt to fetch a feed
+type fetchLogEntry struct {
+	startedAt  time.Time
+	statusCode int
+	bytes      int
+	itemsSeen  int
+	itemsNew   int
+	itemErr    error // the first item that could not be saved
+	err        error // the error that ended the attempt
+}
+
+// write a fetch attempt to the fetch_log table
+func writeFetchLog(s *state, feed database.Feed, entry fetchLogEntry) error {
+	// the fetch error wins over item errors
+	errText := sql.NullString{}
+	if entry.err != nil {
+		errText = getNullString(entry.err.Error())
+	} else if entry.itemErr != nil {
+		errText = getNullString("item failed: " + entry.itemErr.Error())
+	}
+
+	// use a context that outlives a shutdown so interrupted attempts are logged too
+	return s.db.CreateFetchLog(context.WithoutCancel(s.ctx), database.CreateFetchLogParams{
+		FeedID:     feed.ID,
+		StartedAt:  entry.startedAt,
+		FinishedAt: time.Now(),
+		StatusCode: getNullInt32(int32(entry.statusCode)),
+		Bytes:      int32(entry.bytes),
+		ItemsSeen:  int32(entry.itemsSeen),
+		ItemsNew:   int32(entry.itemsNew),
+		Error:      errText,
+	})
+}
+
+// print the most recent fetch attempts, for every feed or for a single feed
+func handlerFetchLog(s *state, cmd command) error {
+	var entries []database.GetFetchLogRow
+	if len(cmd.args) > 0 {
+		// get the feed from the url or name
+		feed, err := getFeedByUrlOrName(s, cmd.args[0])
+		if err != nil {
+			return err
+		}
+
+		rows, err := s.db.GetFetchLogForFeed(s.ctx, database.GetFetchLogForFeedParams{
+			FeedID: feed.ID,
+			Limit:  defaultFetchLogLimit,
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			entries = append(entries, database.GetFetchLogRow(row))
+		}
+	} else {
+		rows, err := s.db.GetFetchLog(s.ctx, defaultFetchLogLimit)
+		if err != nil {
+			return err
+		}
+		entries = rows
+	}
+
+	// print the entries
+	for _, entry := range entries {
+		status := "no response"
+		if entry.StatusCode.Valid {
+			status = fmt.Sprint(entry.StatusCode.Int32)
+		}
+		fmt.Printf("%s %s: %s, %d bytes, %d items (%d new) in %s\n",
+			entry.StartedAt.Format(time.DateTime),
+			entry.FeedName.String,
+			status,
+			entry.Bytes,
+			entry.ItemsSeen,
+			entry.ItemsNew,
+			entry.FinishedAt.Sub(entry.StartedAt).Round(time.Millisecond),
+		)
+		if entry.Error.Valid {
+			fmt.Println("  error:", entry.Error.String)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// the Fever API version gator speaks
+	feverAPIVersion = 3
+	// Fever returns at most this many items per request
+	feverMaxItems = 50
+	// every feed uses the same favicon, gator does not fetch them
+	feverFaviconID = 1
+	// a transparent 1x1 gif
+	feverFaviconData = "image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"
+)
+
+type feverGroup struct {
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+}
+
+type feverFeedsGroup struct {
+	GroupID int32  `json:"group_id"`
+	FeedIDs string `json:"feed_ids"`
+}
+
+type feverFeed struct {
+	ID                int32  `json:"id"`
+	FaviconID         int    `json:"favicon_id"`
+	Title             string `json:"title"`
+	URL               string `json:"url"`
+	SiteURL           string `json:"site_url"`
+	IsSpark           int    `json:"is_spark"`
+	LastUpdatedOnTime int64  `json:"last_updated_on_time"`
+}
+
+type feverFavicon struct {
+	ID   int    `json:"id"`
+	Data string `json:"data"`
+}
+
+type feverItem struct {
+	ID            int32  `json:"id"`
+	FeedID        int32  `json:"feed_id"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	HTML          string `json:"html"`
+	URL           string `json:"url"`
+	IsSaved       int    `json:"is_saved"`
+	IsRead        int    `json:"is_read"`
+	CreatedOnTime int64  `json:"created_on_time"`
+}
+
+// POST /fever/?api, one endpoint that answers every query parameter it is given.
+// Clients log in with the user name and an api token as the password
+func handleFever(s *state) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, "invalid request: %v", err))
+			return
+		}
+		if _, ok := r.URL.Query()["api"]; !ok {
+			writeAPIError(w, newAPIError(http.StatusNotFound, "no such endpoint"))
+			return
+		}
+
+		response := map[string]any{"api_version": feverAPIVersion, "auth": 0}
+
+		// an unknown key is not an http error, the client reads auth
+		user, err := s.db.GetUserByFeverKey(r.Context(), database.GetUserByFeverKeyParams{
+			LastUsedAt: getNullTimeNow(),
+			FeverKey:   sql.NullString{String: strings.ToLower(r.FormValue("api_key")), Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			writeJSON(w, http.StatusOK, response)
+			return
+		}
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		response["auth"] = 1
+
+		if err := feverRespond(s, r, user, response); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, response)
+	})
+}
+
+// add the answer to each query parameter of a request to the response
+func feverRespond(s *state, r *http.Request, user database.User, response map[string]any) error {
+	query := r.URL.Query()
+	userID := uuid.NullUUID{UUID: user.ID, Valid: true}
+
+	follows, err := s.db.GetFeedFollowsForUser(r.Context(), user.Name)
+	if err != nil {
+		return err
+	}
+	lastRefreshed := int64(0)
+	for _, follow := range follows {
+		if follow.LastFetchedAt.Valid {
+			lastRefreshed = max(lastRefreshed, follow.LastFetchedAt.Time.Unix())
+		}
+	}
+	response["last_refreshed_on_time"] = lastRefreshed
+
+	// marks are applied first so the id lists below include them
+	if r.FormValue("mark") != "" {
+		if err := feverMark(s, r, user); err != nil {
+			return err
+		}
+	}
+
+	if query.Has("groups") {
+		groups, err := feverGroups(s, r, user)
+		if err != nil {
+			return err
+		}
+		response["groups"] = groups
+	}
+	if query.Has("groups") || query.Has("feeds") {
+		feedsGroups, err := feverFeedsGroups(s, r, userID)
+		if err != nil {
+			return err
+		}
+		response["feeds_groups"] = feedsGroups
+	}
+
+	if query.Has("feeds") {
+		feeds := []feverFeed{}
+		for _, follow := range follows {
+			feed := feverFeed{
+				ID:        follow.FeedID,
+				FaviconID: feverFaviconID,
+				Title:     follow.FeedName.String,
+				URL:       follow.FeedUrl.String,
+				SiteURL:   follow.SiteUrl.String,
+			}
+			if follow.LastFetchedAt.Valid {
+				feed.LastUpdatedOnTime = follow.LastFetchedAt.Time.Unix()
+			}
+			feeds = append(feeds, feed)
+		}
+		response["feeds"] = feeds
+	}
+
+	if query.Has("favicons") {
+		response["favicons"] = []feverFavicon{{ID: feverFaviconID, Data: feverFaviconData}}
+	}
+
+	if query.Has("items") {
+		items, err := feverItems(s, r, userID)
+		if err != nil {
+			return err
+		}
+		total, err := s.db.CountPostsForUser(r.Context(), userID)
+		if err != nil {
+			return err
+		}
+		response["items"] = items
+		response["total_items"] = total
+	}
+
+	// gator has no hot links
+	if query.Has("links") {
+		response["links"] = []any{}
+	}
+
+	if query.Has("unread_item_ids") || r.FormValue("mark") != "" {
+		ids, err := s.db.GetUnreadPostIds(r.Context(), userID)
+		if err != nil {
+			return err
+		}
+		response["unread_item_ids"] = joinIDs(ids)
+	}
+
+	if query.Has("saved_item_ids") || r.FormValue("mark") != "" {
+		ids, err := s.db.GetStarredPostIds(r.Context(), user.ID)
+		if err != nil {
+			return err
+		}
+		saved := make([]int32, len(ids))
+		for i, id := range ids {
+			saved[i] = id.Int32
+		}
+		response["saved_item_ids"] = joinIDs(saved)
+	}
+	return nil
+}
+
+// get the items asked for by with_ids, since_id or max_id.
+// since_id pages forward from the oldest item, max_id pages backward from the newest
+func feverItems(s *state, r *http.Request, userID uuid.NullUUID) ([]feverItem, error) {
+	query := r.URL.Query()
+	items := []feverItem{}
+
+	if query.Has("with_ids") {
+		var ids []int32
+		for _, field := range strings.Split(query.Get("with_ids"), ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(field), 10, 32)
+			if err != nil {
+				return nil, newAPIError(http.StatusBadRequest, "invalid with_ids %q", query.Get("with_ids"))
+			}
+			ids = append(ids, int32(id))
+		}
+		if len(ids) > feverMaxItems {
+			ids = ids[:feverMaxItems]
+		}
+		posts, err := s.db.GetFeverItemsByIds(r.Context(), database.GetFeverItemsByIdsParams{UserID: userID, Ids: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			items = append(items, toFeverItem(database.GetFeverItemsRow(post)))
+		}
+		return items, nil
+	}
+
+	params := database.GetFeverItemsParams{
+		UserID:   userID,
+		SinceID:  0,
+		MaxID:    1<<31 - 1,
+		MaxItems: feverMaxItems,
+	}
+	if query.Has("max_id") {
+		maxID, err := strconv.ParseInt(query.Get("max_id"), 10, 32)
+		if err != nil {
+			return nil, newAPIError(http.StatusBadRequest, "invalid max_id %q", query.Get("max_id"))
+		}
+		if maxID > 0 {
+			params.MaxID = int32(maxID)
+		}
+		params.NewestFirst = true
+	} else if query.Has("since_id") {
+		sinceID, err := strconv.ParseInt(query.Get("since_id"), 10, 32)
+		if err != nil {
+			return nil, newAPIError(http.StatusBadRequest, "invalid since_id %q", query.Get("since_id"))
+		}
+		params.SinceID = int32(sinceID)
+	}
+
+	posts, err := s.db.GetFeverItems(r.Context(), params)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		items = append(items, toFeverItem(post))
+	}
+	return items, nil
+}
+
+func toFeverItem(post database.GetFeverItemsRow) feverItem {
+	item := feverItem{
+		ID:     post.ID,
+		FeedID: post.FeedID,
+		Title:  post.Title,
+		HTML:   post.Description.String,
+		URL:    post.Url,
+	}
+	if post.IsRead {
+		item.IsRead = 1
+	}
+	if post.IsSaved {
+		item.IsSaved = 1
+	}
+	if post.PublishedAt.Valid {
+		item.CreatedOnTime = post.PublishedAt.Time.Unix()
+	} else {
+		item.CreatedOnTime = post.CreatedAt.Time.Unix()
+	}
+	return item
+}
+
+// apply mark=item|feed|group with as=read|unread|saved|unsaved to the given id
+func feverMark(s *state, r *http.Request, user database.User) error {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 32)
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "invalid id %q", r.FormValue("id"))
+	}
+	timeNow := time.Now()
+
+	// feeds and groups are marked read up to the time the client last refreshed
+	before := sql.NullTime{Time: timeNow, Valid: true}
+	if value := r.FormValue("before"); value != "" {
+		unix, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return newAPIError(http.StatusBadRequest, "invalid before %q", value)
+		}
+		before.Time = time.Unix(unix, 0)
+	}
+
+	switch mark, as := r.FormValue("mark"), r.FormValue("as"); {
+	case mark == "item" && as == "read":
+		_, err = s.db.MarkPostRead(r.Context(), database.MarkPostReadParams{UserID: user.ID, ReadAt: timeNow, PostID: int32(id)})
+	case mark == "item" && as == "unread":
+		_, err = s.db.MarkPostUnread(r.Context(), database.MarkPostUnreadParams{UserID: user.ID, PostID: int32(id)})
+	case mark == "item" && as == "saved":
+		_, err = s.db.StarPost(r.Context(), database.StarPostParams{CreatedAt: timeNow, UserID: user.ID, PostID: int32(id)})
+	case mark == "item" && as == "unsaved":
+		_, err = s.db.UnstarPost(r.Context(), database.UnstarPostParams{UserID: user.ID, PostID: sql.NullInt32{Int32: int32(id), Valid: true}})
+	case mark == "feed" && as == "read":
+		_, err = s.db.MarkFeedRead(r.Context(), database.MarkFeedReadParams{UserID: user.ID, ReadAt: timeNow, FeedID: int32(id), CreatedAt: before})
+	// group 0 is every feed
+	case mark == "group" && as == "read" && id == 0:
+		_, err = s.db.MarkAllRead(r.Context(), database.MarkAllReadParams{UserID: uuid.NullUUID{UUID: user.ID, Valid: true}, ReadAt: timeNow, CreatedAt: before})
+	case mark == "group" && as == "read":
+		_, err = s.db.MarkFolderRead(r.Context(), database.MarkFolderReadParams{UserID: uuid.NullUUID{UUID: user.ID, Valid: true}, ReadAt: timeNow, FolderID: int32(id), CreatedAt: before})
+	default:
+		return newAPIError(http.StatusBadRequest, "can not mark %s as %s", mark, as)
+	}
+	return err
+}
+
+// folders are the Fever groups
+func feverGroups(s *state, r *http.Request, user database.User) ([]feverGroup, error) {
+	folders, err := s.db.GetFoldersForUser(r.Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	groups := []feverGroup{}
+	for _, folder := range folders {
+		groups = append(groups, feverGroup{ID: folder.ID, Title: folder.Name})
+	}
+	return groups, nil
+}
+
+// the feeds in each group
+func feverFeedsGroups(s *state, r *http.Request, userID uuid.NullUUID) ([]feverFeedsGroup, error) {
+	rows, err := s.db.GetFolderFeedsForUser(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	// rows are ordered by folder, so each group is one run of rows
+	feedsGroups := []feverFeedsGroup{}
+	for _, row := range rows {
+		id := strconv.Itoa(int(row.FeedID.Int32))
+		if n := len(feedsGroups); n > 0 && feedsGroups[n-1].GroupID == row.FolderID {
+			feedsGroups[n-1].FeedIDs += "," + id
+			continue
+		}
+		feedsGroups = append(feedsGroups, feverFeedsGroup{GroupID: row.FolderID, FeedIDs: id})
+	}
+	return feedsGroups, nil
+}
+
+// Fever sends id lists as comma separated strings
+func joinIDs(ids []int32) string {
+	fields := make([]string, len(ids))
+	for i, id := range ids {
+		fields[i] = strconv.Itoa(int(id))
+	}
+	return strings.Join(fields, ",")
+}
+
+// the key Fever clients send, the md5 of "user:password" where the password is an api token
+func feverKey(userName, token string) string {
+	sum := md5.Sum([]byte(userName + ":" + token))
+	return hex.EncodeToString(sum[:])
+}
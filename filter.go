@@ -0,0 +1,191 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+// what a filter rule does with the posts it matches
+var filterActions = []string{"hide", "drop", "highlight"}
+
+// the parts of a post a filter rule can match
+var filterFields = []string{"title", "description", "url", "author"}
+
+// a filter rule as given on the command line
+type filterRule struct {
+	feedID    sql.NullInt32
+	field     string
+	matchType string
+	pattern   string
+}
+
+// manage the filter rules of the user. hide keeps matching posts out of browse,
+// drop also skips them when feeds are fetched and highlight marks them
+func handlerFilter(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("filter command requires add, list, remove or test")
+	}
+
+	switch cmd.args[0] {
+	case "add":
+		fs := flag.NewFlagSet("filter add", flag.ContinueOnError)
+		parseRule := filterRuleFlags(fs)
+		args, err := parseArgs(fs, cmd.args[1:])
+		if err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("filter add requires hide, drop or highlight and a pattern")
+		}
+		if !slices.Contains(filterActions, args[0]) {
+			return fmt.Errorf("unknown filter action %q, use hide, drop or highlight", args[0])
+		}
+		rule, err := parseRule(s, args[1])
+		if err != nil {
+			return err
+		}
+		filter, err := s.db.CreateFilter(s.ctx, database.CreateFilterParams{
+			CreatedAt: time.Now(),
+			UserID:    user.ID,
+			FeedID:    rule.feedID,
+			Field:     rule.field,
+			MatchType: rule.matchType,
+			Pattern:   rule.pattern,
+			Action:    args[0],
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Added filter %d\n", filter.ID)
+
+	case "list":
+		filters, err := s.db.GetFiltersForUser(s.ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if len(filters) == 0 {
+			fmt.Println("No filters")
+		}
+		for _, filter := range filters {
+			scope := "all feeds"
+			if filter.FeedID.Valid {
+				scope = filter.FeedName.String
+			}
+			fmt.Printf("%d: %s posts whose %s %s %q, in %s\n", filter.ID, filter.Action, filter.Field, filterVerb(filter.MatchType), filter.Pattern, scope)
+		}
+
+	case "remove":
+		if len(cmd.args) < 2 {
+			return fmt.Errorf("filter remove requires a filter id")
+		}
+		id, err := strconv.ParseInt(cmd.args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid filter id %q", cmd.args[1])
+		}
+		removed, err := s.db.DeleteFilter(s.ctx, database.DeleteFilterParams{ID: int32(id), UserID: user.ID})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return fmt.Errorf("there is no filter %d", id)
+		}
+		fmt.Println("Removed filter:", id)
+
+	// show which of the recent posts of the followed feeds a rule would match
+	case "test":
+		fs := flag.NewFlagSet("filter test", flag.ContinueOnError)
+		parseRule := filterRuleFlags(fs)
+		limit := fs.Int("n", 10, "how many matching posts to show")
+		args, err := parseArgs(fs, cmd.args[1:])
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 {
+			return fmt.Errorf("filter test requires a pattern")
+		}
+		if *limit < 1 {
+			return fmt.Errorf("the number of posts must be a positive number")
+		}
+		rule, err := parseRule(s, args[0])
+		if err != nil {
+			return err
+		}
+		posts, err := s.db.GetPostsMatchingFilter(s.ctx, database.GetPostsMatchingFilterParams{
+			UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+			FeedID:    rule.feedID,
+			Field:     rule.field,
+			MatchType: rule.matchType,
+			Pattern:   rule.pattern,
+			Limit:     int32(*limit),
+		})
+		if err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			fmt.Println("No posts match")
+		}
+		for _, post := range posts {
+			fmt.Printf("-- Post %d, %s, %s\n", post.ID, post.FeedName.String, post.PublishedAt.Time.Format("2006-01-02"))
+			fmt.Println(post.Title)
+		}
+
+	default:
+		return fmt.Errorf("unknown filter command %q", cmd.args[0])
+	}
+	return nil
+}
+
+// add the --field, --regex and --feed flags of a rule, the returned function
+// builds the rule from the pattern once the flags are parsed
+func filterRuleFlags(fs *flag.FlagSet) func(s *state, pattern string) (filterRule, error) {
+	field := fs.String("field", "title", "the part of the post to match: title, description, url or author")
+	regex := fs.Bool("regex", false, "the pattern is a regular expression instead of a substring")
+	feedName := fs.String("feed", "", "only match posts of a feed, by url or name")
+
+	return func(s *state, pattern string) (filterRule, error) {
+		rule := filterRule{field: *field, matchType: "substring", pattern: pattern}
+		if !slices.Contains(filterFields, rule.field) {
+			return filterRule{}, fmt.Errorf("unknown filter field %q, use title, description, url or author", rule.field)
+		}
+		if pattern == "" {
+			return filterRule{}, fmt.Errorf("the filter pattern is empty")
+		}
+
+		// patterns are matched by postgres, so it checks them too
+		if *regex {
+			rule.matchType = "regex"
+			if _, err := s.db.CheckFilterRegex(s.ctx, pattern); err != nil {
+				return filterRule{}, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+			}
+		}
+
+		if *feedName != "" {
+			feed, err := getFeedByUrlOrName(s, *feedName)
+			if errors.Is(err, sql.ErrNoRows) {
+				return filterRule{}, fmt.Errorf("there is no feed %s", *feedName)
+			}
+			if err != nil {
+				return filterRule{}, err
+			}
+			rule.feedID = sql.NullInt32{Int32: feed.ID, Valid: true}
+		}
+		return rule, nil
+	}
+}
+
+// describe how a rule matches in filter list
+func filterVerb(matchType string) string {
+	if matchType == "regex" {
+		return "matches"
+	}
+	return "contains"
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+// manage the folders of the user, a feed can be in any number of folders.
+// Nested folders are named by their path, like tech/go
+func handlerFolder(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("folder command requires create, rename, delete, add, remove, move or list")
+	}
+	args := cmd.args[1:]
+
+	switch cmd.args[0] {
+	case "create":
+		if len(args) < 1 {
+			return fmt.Errorf("folder create requires a name")
+		}
+		name, err := cleanFolderName(args[0])
+		if err != nil {
+			return err
+		}
+		timeNow := time.Now()
+		folder, err := s.db.CreateFolder(s.ctx, database.CreateFolderParams{
+			CreatedAt: timeNow,
+			UpdatedAt: timeNow,
+			UserID:    user.ID,
+			Name:      name,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created folder:", folder.Name)
+
+	// subfolders are renamed with their folder, tech/go becomes news/go
+	case "rename":
+		if len(args) < 2 {
+			return fmt.Errorf("folder rename requires the old and the new name")
+		}
+		oldName, err := cleanFolderName(args[0])
+		if err != nil {
+			return err
+		}
+		name, err := cleanFolderName(args[1])
+		if err != nil {
+			return err
+		}
+		if err := checkFolderRename(s, user, oldName, name); err != nil {
+			return err
+		}
+		renamed, err := s.db.RenameFolder(s.ctx, database.RenameFolderParams{
+			UpdatedAt: time.Now(),
+			NewName:   name,
+			OldName:   oldName,
+			UserID:    user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if renamed == 0 {
+			return fmt.Errorf("there is no folder %s", oldName)
+		}
+		fmt.Printf("Renamed folder %s to %s\n", oldName, name)
+
+	// subfolders are deleted with their folder, the feeds stay followed
+	case "delete":
+		if len(args) < 1 {
+			return fmt.Errorf("folder delete requires a name")
+		}
+		name, err := cleanFolderName(args[0])
+		if err != nil {
+			return err
+		}
+		deleted, err := s.db.DeleteFolder(s.ctx, database.DeleteFolderParams{UserID: user.ID, Name: name})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return fmt.Errorf("there is no folder %s", name)
+		}
+		fmt.Println("Deleted folder:", name)
+
+	case "add":
+		if len(args) < 2 {
+			return fmt.Errorf("folder add requires a folder and a feed url or name")
+		}
+		follow, err := getFollowedFeed(s, user, args[1])
+		if err != nil {
+			return err
+		}
+		folder, err := getOrCreateFolder(s, user, args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.AddFeedFollowToFolder(s.ctx, database.AddFeedFollowToFolderParams{
+			FolderID:     folder.ID,
+			FeedFollowID: follow.ID,
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("Added %s to %s\n", args[1], folder.Name)
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("folder remove requires a folder and a feed url or name")
+		}
+		folder, err := getFolder(s, user, args[0])
+		if err != nil {
+			return err
+		}
+		follow, err := getFollowedFeed(s, user, args[1])
+		if err != nil {
+			return err
+		}
+		removed, err := s.db.RemoveFeedFollowFromFolder(s.ctx, database.RemoveFeedFollowFromFolderParams{
+			FolderID:     folder.ID,
+			FeedFollowID: follow.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return fmt.Errorf("%s is not in %s", args[1], folder.Name)
+		}
+		fmt.Printf("Removed %s from %s\n", args[1], folder.Name)
+
+	// move takes a feed out of all its folders, with no folder it is left unfiled
+	case "move":
+		if len(args) < 1 {
+			return fmt.Errorf("folder move requires a feed url or name and optionally a folder")
+		}
+		follow, err := getFollowedFeed(s, user, args[0])
+		if err != nil {
+			return err
+		}
+		var folder database.Folder
+		if len(args) > 1 {
+			folder, err = getOrCreateFolder(s, user, args[1])
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.db.RemoveFeedFollowFromFolders(s.ctx, follow.ID); err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			fmt.Printf("Moved %s out of its folders\n", args[0])
+			return nil
+		}
+		if _, err := s.db.AddFeedFollowToFolder(s.ctx, database.AddFeedFollowToFolderParams{
+			FolderID:     folder.ID,
+			FeedFollowID: follow.ID,
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("Moved %s to %s\n", args[0], folder.Name)
+
+	case "list":
+		folders, err := s.db.GetFoldersForUser(s.ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		rows, err := s.db.GetFolderFeedsForUser(s.ctx, uuid.NullUUID{UUID: user.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		feeds := map[int32]int{}
+		for _, row := range rows {
+			feeds[row.FolderID]++
+		}
+		if len(folders) == 0 {
+			fmt.Println("No folders")
+		}
+		for _, folder := range folders {
+			fmt.Printf("%s (%d feeds)\n", folder.Name, feeds[folder.ID])
+		}
+
+	default:
+		return fmt.Errorf("unknown folder command %q", cmd.args[0])
+	}
+	return nil
+}
+
+// trim a folder name, surrounding and repeated slashes are dropped
+func cleanFolderName(name string) (string, error) {
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("invalid folder name %q", name)
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// check that renaming a folder and its subfolders does not clash with a folder
+// that is not renamed
+func checkFolderRename(s *state, user database.User, oldName, name string) error {
+	if _, err := s.db.GetFolderByName(s.ctx, database.GetFolderByNameParams{UserID: user.ID, Name: name}); err == nil {
+		if !inFolder(name, oldName) {
+			return fmt.Errorf("folder %s already exists", name)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	folders, err := s.db.GetFoldersForUser(s.ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, folder := range folders {
+		if !inFolder(folder.Name, oldName) {
+			existing[folder.Name] = true
+		}
+	}
+	for _, folder := range folders {
+		if !inFolder(folder.Name, oldName) {
+			continue
+		}
+		if newName := name + strings.TrimPrefix(folder.Name, oldName); existing[newName] {
+			return fmt.Errorf("folder %s already exists", newName)
+		}
+	}
+	return nil
+}
+
+// report whether a folder is the given one or one of its subfolders
+func inFolder(name, folder string) bool {
+	return name == folder || strings.HasPrefix(name, folder+"/")
+}
+
+// get a folder of the user by name
+func getFolder(s *state, user database.User, name string) (database.Folder, error) {
+	cleanName, err := cleanFolderName(name)
+	if err != nil {
+		return database.Folder{}, err
+	}
+	folder, err := s.db.GetFolderByName(s.ctx, database.GetFolderByNameParams{UserID: user.ID, Name: cleanName})
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.Folder{}, fmt.Errorf("there is no folder %s", cleanName)
+	}
+	return folder, err
+}
+
+// get a folder of the user by name, creating it if it does not exist
+func getOrCreateFolder(s *state, user database.User, name string) (database.Folder, error) {
+	cleanName, err := cleanFolderName(name)
+	if err != nil {
+		return database.Folder{}, err
+	}
+	folder, err := s.db.GetFolderByName(s.ctx, database.GetFolderByNameParams{UserID: user.ID, Name: cleanName})
+	if !errors.Is(err, sql.ErrNoRows) {
+		return folder, err
+	}
+	timeNow := time.Now()
+	return s.db.CreateFolder(s.ctx, database.CreateFolderParams{
+		CreatedAt: timeNow,
+		UpdatedAt: timeNow,
+		UserID:    user.ID,
+		Name:      cleanName,
+	})
+}
+
+// get the follow of a feed by url or name
+func getFollowedFeed(s *state, user database.User, urlOrName string) (database.FeedFollow, error) {
+	feed, err := getFeedByUrlOrName(s, urlOrName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.FeedFollow{}, fmt.Errorf("there is no feed %s", urlOrName)
+	}
+	if err != nil {
+		return database.FeedFollow{}, err
+	}
+	follow, err := s.db.GetFeedFollow(s.ctx, database.GetFeedFollowParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		FeedID: sql.NullInt32{Int32: feed.ID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.FeedFollow{}, fmt.Errorf("%s does not follow %s", user.Name, feed.Name.String)
+	}
+	return follow, err
+}
+
+// a folder in the following --tree view
+type folderNode struct {
+	feeds    []string
+	children map[string]*folderNode
+}
+
+// get the node of a folder path, adding the folders on the way
+func (node *folderNode) child(path string) *folderNode {
+	for _, name := range strings.Split(path, "/") {
+		next, ok := node.children[name]
+		if !ok {
+			next = &folderNode{children: map[string]*folderNode{}}
+			node.children[name] = next
+		}
+		node = next
+	}
+	return node
+}
+
+// print the folders by name, each followed by its feeds
+func (node *folderNode) print(depth int) {
+	indent := strings.Repeat("  ", depth)
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s%s/\n", indent, name)
+		node.children[name].print(depth + 1)
+	}
+	for _, feed := range node.feeds {
+		fmt.Printf("%s%s\n", indent, feed)
+	}
+}
@@ -0,0 +1,659 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// how many items a stream returns when no count is given, and the most it returns
+	defaultReaderItems = 20
+	maxReaderItems     = 1000
+
+	readerReadingList = "user/-/state/com.google/reading-list"
+	readerRead        = "user/-/state/com.google/read"
+	readerStarred     = "user/-/state/com.google/starred"
+	readerKeptUnread  = "user/-/state/com.google/kept-unread"
+	readerLabelPrefix = "user/-/label/"
+	readerFeedPrefix  = "feed/"
+	readerItemPrefix  = "tag:google.com,2005:reader/item/"
+)
+
+type readerSubscription struct {
+	ID         string           `json:"id"`
+	Title      string           `json:"title"`
+	Categories []readerCategory `json:"categories"`
+	URL        string           `json:"url"`
+	HTMLURL    string           `json:"htmlUrl"`
+	IconURL    string           `json:"iconUrl"`
+}
+
+type readerCategory struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+type readerItem struct {
+	ID            string        `json:"id"`
+	CrawlTimeMsec string        `json:"crawlTimeMsec"`
+	TimestampUsec string        `json:"timestampUsec"`
+	Published     int64         `json:"published"`
+	Updated       int64         `json:"updated"`
+	Title         string        `json:"title"`
+	Canonical     []readerLink  `json:"canonical"`
+	Alternate     []readerLink  `json:"alternate"`
+	Summary       readerContent `json:"summary"`
+	Categories    []string      `json:"categories"`
+	Origin        readerOrigin  `json:"origin"`
+	Annotations   []any         `json:"annotations"`
+	Likers        []any         `json:"likingUsers"`
+	Comments      []any         `json:"comments"`
+}
+
+type readerLink struct {
+	Href string `json:"href"`
+	Type string `json:"type,omitempty"`
+}
+
+type readerContent struct {
+	Direction string `json:"direction"`
+	Content   string `json:"content"`
+}
+
+type readerOrigin struct {
+	StreamID string `json:"streamId"`
+	Title    string `json:"title"`
+	HTMLURL  string `json:"htmlUrl"`
+}
+
+type readerItemRef struct {
+	ID              string   `json:"id"`
+	DirectStreamIDs []string `json:"directStreamIds"`
+	TimestampUsec   string   `json:"timestampUsec"`
+}
+
+// register the Google Reader routes. Clients log in with ClientLogin using the
+// user name and an api token as the password, and send the token back as
+// "Authorization: GoogleLogin auth=<token>"
+func registerReaderRoutes(s *state, mux *http.ServeMux) {
+	mux.HandleFunc("/accounts/ClientLogin", func(w http.ResponseWriter, r *http.Request) {
+		if err := readerClientLogin(s, w, r); err != nil {
+			writeReaderError(w, err)
+		}
+	})
+	mux.Handle("GET /reader/api/0/token", readerAuth(s, readerToken))
+	mux.Handle("GET /reader/api/0/user-info", readerAuth(s, readerUserInfo))
+	mux.Handle("GET /reader/api/0/subscription/list", readerAuth(s, readerSubscriptionList))
+	mux.Handle("GET /reader/api/0/tag/list", readerAuth(s, readerTagList))
+	mux.Handle("GET /reader/api/0/unread-count", readerAuth(s, readerUnreadCount))
+	mux.Handle("GET /reader/api/0/stream/contents/{stream...}", readerAuth(s, readerStreamContents))
+	mux.Handle("GET /reader/api/0/stream/items/ids", readerAuth(s, readerStreamItemIDs))
+	mux.Handle("/reader/api/0/stream/items/contents", readerAuth(s, readerStreamItemContents))
+	mux.Handle("POST /reader/api/0/edit-tag", readerAuth(s, readerEditTag))
+	mux.Handle("POST /reader/api/0/mark-all-as-read", readerAuth(s, readerMarkAllAsRead))
+}
+
+// check the GoogleLogin token of a request then run the handler with its user
+func readerAuth(s *state, handler func(s *state, w http.ResponseWriter, r *http.Request, user database.User) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "GoogleLogin auth=")
+		if !ok || token == "" {
+			writeReaderError(w, newAPIError(http.StatusUnauthorized, "missing GoogleLogin auth"))
+			return
+		}
+
+		user, err := s.db.GetUserByApiToken(r.Context(), database.GetUserByApiTokenParams{
+			LastUsedAt: getNullTimeNow(),
+			TokenHash:  hashAPIToken(token),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			writeReaderError(w, newAPIError(http.StatusUnauthorized, "invalid auth"))
+			return
+		}
+		if err != nil {
+			writeReaderError(w, err)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			writeReaderError(w, newAPIError(http.StatusBadRequest, "invalid request: %v", err))
+			return
+		}
+		if err := handler(s, w, r, user); err != nil {
+			writeReaderError(w, err)
+		}
+	})
+}
+
+// Google Reader clients expect plain text errors
+func writeReaderError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		writeAPIError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if apiErr.StatusCode == http.StatusUnauthorized {
+		w.Header().Set("Google-Bad-Token", "true")
+	}
+	w.WriteHeader(apiErr.StatusCode)
+	fmt.Fprintln(w, "Error="+apiErr.Message)
+}
+
+// POST /accounts/ClientLogin with Email and Passwd
+func readerClientLogin(s *state, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return newAPIError(http.StatusBadRequest, "invalid request: %v", err)
+	}
+	name, token := r.FormValue("Email"), r.FormValue("Passwd")
+
+	user, err := s.db.GetUserByApiToken(r.Context(), database.GetUserByApiTokenParams{
+		LastUsedAt: getNullTimeNow(),
+		TokenHash:  hashAPIToken(token),
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user.Name != name) {
+		return newAPIError(http.StatusUnauthorized, "BadAuthentication")
+	}
+	if err != nil {
+		return err
+	}
+
+	// the token is the session, there is nothing else to hand out
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if r.FormValue("output") == "json" {
+		return writeJSON(w, http.StatusOK, map[string]string{"SID": token, "LSID": token, "Auth": token})
+	}
+	_, err = fmt.Fprintf(w, "SID=%s\nLSID=%s\nAuth=%s\n", token, token, token)
+	return err
+}
+
+// GET /reader/api/0/token, the edit token clients send with changes.
+// Requests are already authenticated by their header, so any value is accepted back
+func readerToken(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err := fmt.Fprint(w, hashAPIToken(user.ID.String()))
+	return err
+}
+
+// GET /reader/api/0/user-info
+func readerUserInfo(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	return writeJSON(w, http.StatusOK, map[string]string{
+		"userId":        user.ID.String(),
+		"userName":      user.Name,
+		"userProfileId": user.ID.String(),
+		"userEmail":     user.Name,
+	})
+}
+
+// GET /reader/api/0/subscription/list
+func readerSubscriptionList(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	follows, err := s.db.GetFeedFollowsForUser(r.Context(), user.Name)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := []readerSubscription{}
+	for _, follow := range follows {
+		subscription := readerSubscription{
+			ID:         readerFeedID(follow.FeedID),
+			Title:      follow.FeedName.String,
+			Categories: []readerCategory{},
+			URL:        follow.FeedUrl.String,
+			HTMLURL:    follow.SiteUrl.String,
+		}
+		for _, folder := range follow.Folders {
+			subscription.Categories = append(subscription.Categories, readerCategory{
+				ID:    readerLabelPrefix + folder,
+				Label: folder,
+			})
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return writeJSON(w, http.StatusOK, map[string]any{"subscriptions": subscriptions})
+}
+
+// GET /reader/api/0/tag/list, the starred state and a label per folder
+func readerTagList(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	folders, err := s.db.GetFoldersForUser(r.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	tags := []map[string]string{{"id": readerStarred}}
+	for _, folder := range folders {
+		tags = append(tags, map[string]string{"id": readerLabelPrefix + folder.Name, "type": "folder"})
+	}
+	return writeJSON(w, http.StatusOK, map[string]any{"tags": tags})
+}
+
+// GET /reader/api/0/unread-count, per feed, per label and for the reading list
+func readerUnreadCount(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	counts, err := s.db.GetUnreadCounts(r.Context(), uuid.NullUUID{UUID: user.ID, Valid: true})
+	if err != nil {
+		return err
+	}
+	follows, err := s.db.GetFeedFollowsForUser(r.Context(), user.Name)
+	if err != nil {
+		return err
+	}
+	userFolders, err := s.db.GetFoldersForUser(r.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	// a label counts the feeds of its folder and of its subfolders
+	folders := map[int32][]string{}
+	for _, follow := range follows {
+		for _, folder := range userFolders {
+			if slices.ContainsFunc(follow.Folders, func(name string) bool {
+				return inFolder(name, folder.Name)
+			}) {
+				folders[follow.FeedID] = append(folders[follow.FeedID], folder.Name)
+			}
+		}
+	}
+
+	type unreadCount struct {
+		ID                      string `json:"id"`
+		Count                   int64  `json:"count"`
+		NewestItemTimestampUsec string `json:"newestItemTimestampUsec"`
+		newest                  time.Time
+	}
+	var total int64
+	var newest time.Time
+	labels := map[string]*unreadCount{}
+	unreadCounts := []*unreadCount{}
+	for _, count := range counts {
+		total += count.Unread
+		if count.Newest.After(newest) {
+			newest = count.Newest
+		}
+		unreadCounts = append(unreadCounts, &unreadCount{
+			ID:                      readerFeedID(count.FeedID),
+			Count:                   count.Unread,
+			NewestItemTimestampUsec: readerUsec(count.Newest),
+		})
+
+		// labels add up the counts of their feeds
+		for _, folder := range folders[count.FeedID] {
+			label, ok := labels[folder]
+			if !ok {
+				label = &unreadCount{ID: readerLabelPrefix + folder}
+				labels[folder] = label
+				unreadCounts = append(unreadCounts, label)
+			}
+			label.Count += count.Unread
+			if count.Newest.After(label.newest) {
+				label.newest = count.Newest
+			}
+			label.NewestItemTimestampUsec = readerUsec(label.newest)
+		}
+	}
+	unreadCounts = append(unreadCounts, &unreadCount{ID: readerReadingList, Count: total, NewestItemTimestampUsec: readerUsec(newest)})
+
+	return writeJSON(w, http.StatusOK, map[string]any{"max": total, "unreadcounts": unreadCounts})
+}
+
+// GET /reader/api/0/stream/contents/{stream}
+func readerStreamContents(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	streamID := r.PathValue("stream")
+	if streamID == "" {
+		streamID = r.FormValue("s")
+	}
+	posts, continuation, err := readerStream(s, r, user, streamID)
+	if err != nil {
+		return err
+	}
+
+	items := []readerItem{}
+	for _, post := range posts {
+		items = append(items, toReaderItem(post))
+	}
+	response := map[string]any{
+		"id":      streamID,
+		"updated": time.Now().Unix(),
+		"items":   items,
+	}
+	if continuation != "" {
+		response["continuation"] = continuation
+	}
+	return writeJSON(w, http.StatusOK, response)
+}
+
+// GET /reader/api/0/stream/items/ids?s={stream}
+func readerStreamItemIDs(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	posts, continuation, err := readerStream(s, r, user, r.FormValue("s"))
+	if err != nil {
+		return err
+	}
+
+	refs := []readerItemRef{}
+	for _, post := range posts {
+		refs = append(refs, readerItemRef{
+			ID:              strconv.Itoa(int(post.ID)),
+			DirectStreamIDs: []string{},
+			TimestampUsec:   readerUsec(readerTimestamp(post)),
+		})
+	}
+	response := map[string]any{"itemRefs": refs}
+	if continuation != "" {
+		response["continuation"] = continuation
+	}
+	return writeJSON(w, http.StatusOK, response)
+}
+
+// GET or POST /reader/api/0/stream/items/contents with an i for each item
+func readerStreamItemContents(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	ids, err := readerItemIDs(r.Form["i"])
+	if err != nil {
+		return err
+	}
+
+	posts, err := s.db.GetReaderItemsByIds(r.Context(), database.GetReaderItemsByIdsParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Ids:    ids,
+	})
+	if err != nil {
+		return err
+	}
+
+	items := []readerItem{}
+	for _, post := range posts {
+		items = append(items, toReaderItem(database.GetReaderItemsRow(post)))
+	}
+	return writeJSON(w, http.StatusOK, map[string]any{
+		"id":      readerReadingList,
+		"updated": time.Now().Unix(),
+		"items":   items,
+	})
+}
+
+// POST /reader/api/0/edit-tag with an i for each item, a tag to add as a and one to remove as r
+func readerEditTag(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	ids, err := readerItemIDs(r.PostForm["i"])
+	if err != nil {
+		return err
+	}
+	timeNow := time.Now()
+
+	for _, id := range ids {
+		for _, tag := range r.PostForm["a"] {
+			switch readerState(tag) {
+			case readerRead:
+				_, err = s.db.MarkPostRead(r.Context(), database.MarkPostReadParams{UserID: user.ID, ReadAt: timeNow, PostID: id})
+			case readerKeptUnread:
+				_, err = s.db.MarkPostUnread(r.Context(), database.MarkPostUnreadParams{UserID: user.ID, PostID: id})
+			case readerStarred:
+				_, err = s.db.StarPost(r.Context(), database.StarPostParams{CreatedAt: timeNow, UserID: user.ID, PostID: id})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		for _, tag := range r.PostForm["r"] {
+			switch readerState(tag) {
+			case readerRead:
+				_, err = s.db.MarkPostUnread(r.Context(), database.MarkPostUnreadParams{UserID: user.ID, PostID: id})
+			case readerStarred:
+				_, err = s.db.UnstarPost(r.Context(), database.UnstarPostParams{UserID: user.ID, PostID: sql.NullInt32{Int32: id, Valid: true}})
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err = fmt.Fprint(w, "OK")
+	return err
+}
+
+// POST /reader/api/0/mark-all-as-read with the stream as s and optionally a time as ts in microseconds
+func readerMarkAllAsRead(s *state, w http.ResponseWriter, r *http.Request, user database.User) error {
+	timeNow := time.Now()
+	before := sql.NullTime{Time: timeNow, Valid: true}
+	if value := r.PostFormValue("ts"); value != "" {
+		usec, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return newAPIError(http.StatusBadRequest, "invalid ts %q", value)
+		}
+		before.Time = time.UnixMicro(usec)
+	}
+
+	streamID := readerState(r.PostFormValue("s"))
+	switch {
+	case streamID == readerReadingList:
+		if _, err := s.db.MarkAllRead(r.Context(), database.MarkAllReadParams{
+			UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+			ReadAt:    timeNow,
+			CreatedAt: before,
+		}); err != nil {
+			return err
+		}
+
+	case strings.HasPrefix(streamID, readerFeedPrefix):
+		feedID, err := readerParseFeedID(streamID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.MarkFeedRead(r.Context(), database.MarkFeedReadParams{UserID: user.ID, ReadAt: timeNow, FeedID: feedID, CreatedAt: before}); err != nil {
+			return err
+		}
+
+	case strings.HasPrefix(streamID, readerLabelPrefix):
+		folder, err := s.db.GetFolderByName(r.Context(), database.GetFolderByNameParams{
+			UserID: user.ID,
+			Name:   strings.TrimPrefix(streamID, readerLabelPrefix),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return newAPIError(http.StatusBadRequest, "unknown stream %q", streamID)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.MarkFolderRead(r.Context(), database.MarkFolderReadParams{UserID: uuid.NullUUID{UUID: user.ID, Valid: true}, ReadAt: timeNow, FolderID: folder.ID, CreatedAt: before}); err != nil {
+			return err
+		}
+
+	default:
+		return newAPIError(http.StatusBadRequest, "unknown stream %q", streamID)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err := fmt.Fprint(w, "OK")
+	return err
+}
+
+// get a page of a stream, using the n, c, r, xt, it, ot and nt parameters
+func readerStream(s *state, r *http.Request, user database.User, streamID string) ([]database.GetReaderItemsRow, string, error) {
+	params := database.GetReaderItemsParams{
+		UserID:      uuid.NullUUID{UUID: user.ID, Valid: true},
+		AfterID:     0,
+		BeforeID:    1<<31 - 1,
+		OldestFirst: r.FormValue("r") == "o",
+		MaxItems:    defaultReaderItems,
+	}
+
+	streamID = readerState(streamID)
+	switch {
+	case streamID == readerReadingList:
+	case streamID == readerStarred:
+		params.StarredOnly = true
+	case streamID == readerRead:
+		params.ReadOnly = true
+	case strings.HasPrefix(streamID, readerFeedPrefix):
+		feedID, err := readerParseFeedID(streamID)
+		if err != nil {
+			return nil, "", err
+		}
+		params.FeedID = sql.NullInt32{Int32: feedID, Valid: true}
+	case strings.HasPrefix(streamID, readerLabelPrefix):
+		params.Folder = sql.NullString{String: strings.TrimPrefix(streamID, readerLabelPrefix), Valid: true}
+	default:
+		return nil, "", newAPIError(http.StatusBadRequest, "unknown stream %q", streamID)
+	}
+
+	// exclude and include targets
+	switch readerState(r.FormValue("xt")) {
+	case readerRead:
+		params.UnreadOnly = true
+	case readerStarred:
+		return nil, "", newAPIError(http.StatusBadRequest, "excluding starred items is not supported")
+	}
+	switch readerState(r.FormValue("it")) {
+	case readerRead:
+		params.ReadOnly = true
+	case readerStarred:
+		params.StarredOnly = true
+	}
+
+	if value := r.FormValue("n"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return nil, "", newAPIError(http.StatusBadRequest, "invalid n %q", value)
+		}
+		params.MaxItems = int32(min(n, maxReaderItems))
+	}
+
+	// the continuation is the id of the last item of the previous page
+	if value := r.FormValue("c"); value != "" {
+		c, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, "", newAPIError(http.StatusBadRequest, "invalid continuation %q", value)
+		}
+		if params.OldestFirst {
+			params.AfterID = int32(c)
+		} else {
+			params.BeforeID = int32(c)
+		}
+	}
+
+	for name, param := range map[string]*sql.NullTime{"ot": &params.OlderThan, "nt": &params.NewerThan} {
+		value := r.FormValue(name)
+		if value == "" {
+			continue
+		}
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, "", newAPIError(http.StatusBadRequest, "invalid %s %q", name, value)
+		}
+		*param = sql.NullTime{Time: time.Unix(seconds, 0), Valid: true}
+	}
+
+	posts, err := s.db.GetReaderItems(r.Context(), params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// a full page means there may be more
+	continuation := ""
+	if len(posts) > 0 && int32(len(posts)) == params.MaxItems {
+		continuation = strconv.Itoa(int(posts[len(posts)-1].ID))
+	}
+	return posts, continuation, nil
+}
+
+func toReaderItem(post database.GetReaderItemsRow) readerItem {
+	timestamp := readerTimestamp(post)
+	categories := []string{readerReadingList}
+	if post.IsRead {
+		categories = append(categories, readerRead)
+	}
+	if post.IsStarred {
+		categories = append(categories, readerStarred)
+	}
+
+	return readerItem{
+		ID:            fmt.Sprintf("%s%016x", readerItemPrefix, post.ID),
+		CrawlTimeMsec: strconv.FormatInt(post.CreatedAt.Time.UnixMilli(), 10),
+		TimestampUsec: readerUsec(timestamp),
+		Published:     timestamp.Unix(),
+		Updated:       timestamp.Unix(),
+		Title:         post.Title,
+		Canonical:     []readerLink{{Href: post.Url}},
+		Alternate:     []readerLink{{Href: post.Url, Type: "text/html"}},
+		Summary:       readerContent{Direction: "ltr", Content: post.Description.String},
+		Categories:    categories,
+		Origin: readerOrigin{
+			StreamID: readerFeedID(post.FeedID),
+			Title:    post.FeedName.String,
+			HTMLURL:  post.FeedSiteUrl.String,
+		},
+		Annotations: []any{},
+		Likers:      []any{},
+		Comments:    []any{},
+	}
+}
+
+// the publish time of a post, or when it was fetched
+func readerTimestamp(post database.GetReaderItemsRow) time.Time {
+	if post.PublishedAt.Valid {
+		return post.PublishedAt.Time
+	}
+	return post.CreatedAt.Time
+}
+
+func readerUsec(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.UnixMicro(), 10)
+}
+
+func readerFeedID(feedID int32) string {
+	return readerFeedPrefix + strconv.Itoa(int(feedID))
+}
+
+func readerParseFeedID(streamID string) (int32, error) {
+	feedID, err := strconv.ParseInt(strings.TrimPrefix(streamID, readerFeedPrefix), 10, 32)
+	if err != nil {
+		return 0, newAPIError(http.StatusBadRequest, "unknown stream %q", streamID)
+	}
+	return int32(feedID), nil
+}
+
+// clients send states with the user id or with "-" for the current user
+func readerState(streamID string) string {
+	rest, ok := strings.CutPrefix(streamID, "user/")
+	if !ok {
+		return streamID
+	}
+	_, rest, ok = strings.Cut(rest, "/")
+	if !ok {
+		return streamID
+	}
+	return "user/-/" + rest
+}
+
+// item ids are sent as decimals, as 16 hex digits or in the long tag form
+func readerItemIDs(values []string) ([]int32, error) {
+	var ids []int32
+	for _, value := range values {
+		var id int64
+		var err error
+		if hexID, ok := strings.CutPrefix(value, readerItemPrefix); ok {
+			id, err = strconv.ParseInt(hexID, 16, 64)
+		} else if len(value) == 16 {
+			id, err = strconv.ParseInt(value, 16, 64)
+		} else {
+			id, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil || id < 0 || id > 1<<31-1 {
+			return nil, newAPIError(http.StatusBadRequest, "invalid item id %q", value)
+		}
+		ids = append(ids, int32(id))
+	}
+	if len(ids) == 0 {
+		return nil, newAPIError(http.StatusBadRequest, "no item ids given")
+	}
+	return ids, nil
+}
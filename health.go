@@ -0,0 +1,112 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gator/internal/database"
+)
+
+const (
+	// feeds are disabled after this many failed fetches in a row
+	disableAfterFailures = 10
+	// the longest a failing feed waits before it is tried again
+	maxBackoff = 7 * 24 * time.Hour
+)
+
+// record a failed fetch, backing off exponentially and disabling feeds that keep failing
+func recordFeedFailure(s *state, feed database.Feed, fetchErr error, nextFetch time.Time) error {
+	timeNow := getNullTimeNow()
+	failures := feed.ConsecutiveFailures + 1
+
+	// double the wait for every failure in a row
+	backoff := nextFetch.Sub(timeNow.Time)
+	for i := int32(0); i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maxBackoff)
+
+	// a feed that is gone for good is disabled straight away
+	disabledAt := sql.NullTime{}
+	var statusErr *httpStatusError
+	if failures >= disableAfterFailures || (errors.As(fetchErr, &statusErr) && statusErr.StatusCode == http.StatusGone) {
+		disabledAt = timeNow
+	}
+
+	if err := s.db.RecordFeedFailure(s.ctx, database.RecordFeedFailureParams{
+		UpdatedAt:           timeNow,
+		LastFetchedAt:       timeNow,
+		NextFetchAt:         sql.NullTime{Time: timeNow.Time.Add(backoff), Valid: true},
+		LastError:           getNullString(fetchErr.Error()),
+		ConsecutiveFailures: failures,
+		DisabledAt:          disabledAt,
+		ID:                  feed.ID,
+	}); err != nil {
+		return err
+	}
+
+	if disabledAt.Valid {
+		fmt.Printf("%s disabled after %d failed fetches\n", feed.Name.String, failures)
+	}
+	return nil
+}
+
+// list feeds that are failing or disabled, and why
+func handlerFeedHealth(s *state, cmd command) error {
+	// get the list
+	feeds, err := s.db.GetFailingFeeds(s.ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(feeds) == 0 {
+		fmt.Println("All feeds are healthy")
+		return nil
+	}
+
+	// print the list
+	for _, feed := range feeds {
+		fmt.Println("Feed:", feed.Name.String)
+		fmt.Println("URL:", feed.Url.String)
+		fmt.Println("Failures in a row:", feed.ConsecutiveFailures)
+		fmt.Println("Last error:", feed.LastError.String)
+		if feed.LastSuccessAt.Valid {
+			fmt.Println("Last success:", feed.LastSuccessAt.Time.String())
+		} else {
+			fmt.Println("Last success: never")
+		}
+		if feed.DisabledAt.Valid {
+			fmt.Println("Disabled at:", feed.DisabledAt.Time.String())
+		} else {
+			fmt.Println("Next attempt:", feed.NextFetchAt.Time.String())
+		}
+		fmt.Println("")
+	}
+	return nil
+}
+
+// re-enable a disabled feed and clear its failures
+func handlerEnableFeed(s *state, cmd command) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("enablefeed command requires a url")
+	}
+
+	// get the feed from the url
+	feed, err := s.db.GetFeedByUrl(s.ctx, sql.NullString{String: cmd.args[0], Valid: true})
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.EnableFeed(s.ctx, database.EnableFeedParams{
+		UpdatedAt: getNullTimeNow(),
+		ID:        feed.ID,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println(feed.Name.String, "enabled, it will be fetched on the next agg tick")
+	return nil
+}
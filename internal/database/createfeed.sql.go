@@ -13,15 +13,16 @@ import (
 )
 
 const createFeed = `-- name: CreateFeed :exec
-INSERT INTO feed(created_at, updated_at, name, url, user_id)
+INSERT INTO feed(created_at, updated_at, name, url, user_id, site_url)
 VALUES(
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
-RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, previous_url, next_fetch_at, fetch_interval_seconds, update_hint_seconds, last_error, consecutive_failures, last_success_at, disabled_at, site_url, retention_max_age_seconds, retention_max_posts, keep_forever
 `
 
 type CreateFeedParams struct {
@@ -30,6 +31,7 @@ type CreateFeedParams struct {
 	Name      sql.NullString
 	Url       sql.NullString
 	UserID    uuid.NullUUID
+	SiteUrl   sql.NullString
 }
 
 func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) error {
@@ -39,6 +41,7 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) error {
 		arg.Name,
 		arg.Url,
 		arg.UserID,
+		arg.SiteUrl,
 	)
 	return err
 }
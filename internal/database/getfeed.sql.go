@@ -11,7 +11,7 @@ import (
 )
 
 const getFeed = `-- name: GetFeed :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feed WHERE name = $1
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, previous_url, next_fetch_at, fetch_interval_seconds, update_hint_seconds, last_error, consecutive_failures, last_success_at, disabled_at, site_url, retention_max_age_seconds, retention_max_posts, keep_forever FROM feed WHERE name = $1
 `
 
 func (q *Queries) GetFeed(ctx context.Context, name sql.NullString) (Feed, error) {
@@ -25,6 +25,20 @@ func (q *Queries) GetFeed(ctx context.Context, name sql.NullString) (Feed, error
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.PreviousUrl,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.UpdateHintSeconds,
+		&i.LastError,
+		&i.ConsecutiveFailures,
+		&i.LastSuccessAt,
+		&i.DisabledAt,
+		&i.SiteUrl,
+		&i.RetentionMaxAgeSeconds,
+		&i.RetentionMaxPosts,
+		&i.KeepForever,
 	)
 	return i, err
 }
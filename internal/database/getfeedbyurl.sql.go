@@ -11,7 +11,10 @@ import (
 )
 
 const getFeedByUrl = `-- name: GetFeedByUrl :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feed WHERE url = $1
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, previous_url, next_fetch_at, fetch_interval_seconds, update_hint_seconds, last_error, consecutive_failures, last_success_at, disabled_at, site_url, retention_max_age_seconds, retention_max_posts, keep_forever FROM feed
+WHERE url = $1 OR previous_url = $1
+ORDER BY url = $1 DESC
+LIMIT 1
 `
 
 func (q *Queries) GetFeedByUrl(ctx context.Context, url sql.NullString) (Feed, error) {
@@ -25,6 +28,20 @@ func (q *Queries) GetFeedByUrl(ctx context.Context, url sql.NullString) (Feed, e
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.PreviousUrl,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.UpdateHintSeconds,
+		&i.LastError,
+		&i.ConsecutiveFailures,
+		&i.LastSuccessAt,
+		&i.DisabledAt,
+		&i.SiteUrl,
+		&i.RetentionMaxAgeSeconds,
+		&i.RetentionMaxPosts,
+		&i.KeepForever,
 	)
 	return i, err
 }
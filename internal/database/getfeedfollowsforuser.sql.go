@@ -8,21 +8,37 @@ package database
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const getFeedFollowsForUser = `-- name: GetFeedFollowsForUser :many
-SELECT feed.name AS feed_name, users.name AS user_name
+SELECT feed.name AS feed_name, users.name AS user_name, feed.url AS feed_url, feed.site_url, feed.id AS feed_id, feed.last_fetched_at,
+    feed_follow.id AS feed_follow_id,
+    COALESCE(array_agg(folders.name ORDER BY folders.name) FILTER (WHERE folders.name IS NOT NULL), '{}')::text[] AS folders
 FROM feed_follow
 INNER JOIN users
 ON feed_follow.user_id = users.id 
 INNER JOIN feed
 ON feed_follow.feed_id = feed.id
+LEFT JOIN folder_follows
+ON folder_follows.feed_follow_id = feed_follow.id
+LEFT JOIN folders
+ON folder_follows.folder_id = folders.id
 WHERE users.name = $1
+GROUP BY feed_follow.id, feed.id, users.name
+ORDER BY feed.name
 `
 
 type GetFeedFollowsForUserRow struct {
-	FeedName sql.NullString
-	UserName string
+	FeedName      sql.NullString
+	UserName      string
+	FeedUrl       sql.NullString
+	SiteUrl       sql.NullString
+	FeedID        int32
+	LastFetchedAt sql.NullTime
+	FeedFollowID  int32
+	Folders       []string
 }
 
 func (q *Queries) GetFeedFollowsForUser(ctx context.Context, name string) ([]GetFeedFollowsForUserRow, error) {
@@ -34,7 +50,16 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, name string) ([]Get
 	var items []GetFeedFollowsForUserRow
 	for rows.Next() {
 		var i GetFeedFollowsForUserRow
-		if err := rows.Scan(&i.FeedName, &i.UserName); err != nil {
+		if err := rows.Scan(
+			&i.FeedName,
+			&i.UserName,
+			&i.FeedUrl,
+			&i.SiteUrl,
+			&i.FeedID,
+			&i.LastFetchedAt,
+			&i.FeedFollowID,
+			pq.Array(&i.Folders),
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -10,7 +10,7 @@ import (
 )
 
 const getFeeds = `-- name: GetFeeds :many
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feed
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, previous_url, next_fetch_at, fetch_interval_seconds, update_hint_seconds, last_error, consecutive_failures, last_success_at, disabled_at, site_url, retention_max_age_seconds, retention_max_posts, keep_forever FROM feed
 `
 
 func (q *Queries) GetFeeds(ctx context.Context) ([]Feed, error) {
@@ -30,6 +30,20 @@ func (q *Queries) GetFeeds(ctx context.Context) ([]Feed, error) {
 			&i.Url,
 			&i.UserID,
 			&i.LastFetchedAt,
+			&i.Etag,
+			&i.LastModified,
+			&i.PreviousUrl,
+			&i.NextFetchAt,
+			&i.FetchIntervalSeconds,
+			&i.UpdateHintSeconds,
+			&i.LastError,
+			&i.ConsecutiveFailures,
+			&i.LastSuccessAt,
+			&i.DisabledAt,
+			&i.SiteUrl,
+			&i.RetentionMaxAgeSeconds,
+			&i.RetentionMaxPosts,
+			&i.KeepForever,
 		); err != nil {
 			return nil, err
 		}
@@ -7,18 +7,32 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 const getNextFeedToFetch = `-- name: GetNextFeedToFetch :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at
-FROM feed
-ORDER BY last_fetched_at
-NULLS FIRST
-LIMIT 1
+UPDATE feed
+SET last_fetched_at = $1, next_fetch_at = $2
+WHERE id = (
+    SELECT id
+    FROM feed
+    WHERE disabled_at IS NULL
+        AND (next_fetch_at IS NULL OR next_fetch_at <= $1)
+    ORDER BY next_fetch_at
+    NULLS FIRST
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, previous_url, next_fetch_at, fetch_interval_seconds, update_hint_seconds, last_error, consecutive_failures, last_success_at, disabled_at, site_url, retention_max_age_seconds, retention_max_posts, keep_forever
 `
 
-func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
-	row := q.db.QueryRowContext(ctx, getNextFeedToFetch)
+type GetNextFeedToFetchParams struct {
+	Now        sql.NullTime
+	LeaseUntil sql.NullTime
+}
+
+func (q *Queries) GetNextFeedToFetch(ctx context.Context, arg GetNextFeedToFetchParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, getNextFeedToFetch, arg.Now, arg.LeaseUntil)
 	var i Feed
 	err := row.Scan(
 		&i.ID,
@@ -28,6 +42,20 @@ func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.PreviousUrl,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.UpdateHintSeconds,
+		&i.LastError,
+		&i.ConsecutiveFailures,
+		&i.LastSuccessAt,
+		&i.DisabledAt,
+		&i.SiteUrl,
+		&i.RetentionMaxAgeSeconds,
+		&i.RetentionMaxPosts,
+		&i.KeepForever,
 	)
 	return i, err
 }
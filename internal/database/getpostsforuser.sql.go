@@ -13,38 +13,74 @@ import (
 )
 
 const getPostsForUser = `-- name: GetPostsForUser :many
-WITH get_feed_id (feed_id) AS (
-    SELECT feed_id
-    FROM feed_follow
-    WHERE user_id = $1
-)
-SELECT id, created_at, updated_at, title, url, description, published_at, posts.feed_id, get_feed_id.feed_id
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.guid, posts.author, feed.name AS feed_name, feed.url AS feed_url, feed.site_url AS feed_site_url,
+    EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id) AS is_read,
+    EXISTS (SELECT 1 FROM filters WHERE filters.user_id = feed_follow.user_id AND (filters.feed_id IS NULL OR filters.feed_id = posts.feed_id) AND filters.action = 'highlight' AND filter_matches(filters.field, filters.match_type, filters.pattern, posts.title, posts.description, posts.url, posts.author)) AS is_highlighted
 FROM posts
-INNER JOIN get_feed_id
-ON posts.feed_id = get_feed_id.feed_id
-ORDER BY posts.published_at DESC
-LIMIT $2
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = $1
+    AND ($2::int IS NULL OR posts.feed_id = $2)
+    AND ($3::text IS NULL OR EXISTS (SELECT 1 FROM folder_follows INNER JOIN folders ON folder_follows.folder_id = folders.id WHERE folder_follows.feed_follow_id = feed_follow.id AND (folders.name = $3 OR starts_with(folders.name, $3 || '/'))))
+    AND ($4::timestamp IS NULL OR posts.published_at >= $4)
+    AND ($5::timestamp IS NULL OR (posts.published_at, posts.id) < ($5, $6::int))
+    AND ($7::timestamp IS NULL OR (posts.published_at, posts.id) > ($7, $8::int))
+    AND (NOT $9::bool OR NOT EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id))
+    AND ($10::bool OR NOT EXISTS (SELECT 1 FROM filters WHERE filters.user_id = feed_follow.user_id AND (filters.feed_id IS NULL OR filters.feed_id = posts.feed_id) AND filters.action IN ('hide', 'drop') AND filter_matches(filters.field, filters.match_type, filters.pattern, posts.title, posts.description, posts.url, posts.author)))
+ORDER BY posts.published_at DESC, posts.id DESC
+LIMIT $11 OFFSET $12
 `
 
 type GetPostsForUserParams struct {
-	UserID uuid.NullUUID
-	Limit  int32
+	UserID            uuid.NullUUID
+	FeedID            sql.NullInt32
+	Folder            sql.NullString
+	Since             sql.NullTime
+	BeforePublishedAt sql.NullTime
+	BeforeID          sql.NullInt32
+	AfterPublishedAt  sql.NullTime
+	AfterID           sql.NullInt32
+	UnreadOnly        bool
+	ShowHidden        bool
+	Limit             int32
+	Offset            int32
 }
 
 type GetPostsForUserRow struct {
-	ID          int32
-	CreatedAt   sql.NullTime
-	UpdatedAt   sql.NullTime
-	Title       string
-	Url         string
-	Description sql.NullString
-	PublishedAt sql.NullTime
-	FeedID      int32
-	FeedID_2    sql.NullInt32
+	ID            int32
+	CreatedAt     sql.NullTime
+	UpdatedAt     sql.NullTime
+	Title         string
+	Url           string
+	Description   sql.NullString
+	PublishedAt   sql.NullTime
+	FeedID        int32
+	Guid          string
+	Author        sql.NullString
+	FeedName      sql.NullString
+	FeedUrl       sql.NullString
+	FeedSiteUrl   sql.NullString
+	IsRead        bool
+	IsHighlighted bool
 }
 
 func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams) ([]GetPostsForUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPostsForUser, arg.UserID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getPostsForUser,
+		arg.UserID,
+		arg.FeedID,
+		arg.Folder,
+		arg.Since,
+		arg.BeforePublishedAt,
+		arg.BeforeID,
+		arg.AfterPublishedAt,
+		arg.AfterID,
+		arg.UnreadOnly,
+		arg.ShowHidden,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +97,13 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 			&i.Description,
 			&i.PublishedAt,
 			&i.FeedID,
-			&i.FeedID_2,
+			&i.Guid,
+			&i.Author,
+			&i.FeedName,
+			&i.FeedUrl,
+			&i.FeedSiteUrl,
+			&i.IsRead,
+			&i.IsHighlighted,
 		); err != nil {
 			return nil, err
 		}
@@ -12,17 +12,32 @@ import (
 
 const markFeedFetched = `-- name: MarkFeedFetched :exec
 UPDATE feed
-SET updated_at=$1, last_fetched_at=$2
-WHERE id=$3
+SET updated_at=$1, last_fetched_at=$2, etag=$3, last_modified=$4, next_fetch_at=$5, update_hint_seconds=$6,
+    last_success_at=$2, consecutive_failures=0, last_error=NULL, site_url=$7
+WHERE id=$8
 `
 
 type MarkFeedFetchedParams struct {
-	UpdatedAt     sql.NullTime
-	LastFetchedAt sql.NullTime
-	ID            int32
+	UpdatedAt         sql.NullTime
+	LastFetchedAt     sql.NullTime
+	Etag              sql.NullString
+	LastModified      sql.NullString
+	NextFetchAt       sql.NullTime
+	UpdateHintSeconds sql.NullInt32
+	SiteUrl           sql.NullString
+	ID                int32
 }
 
 func (q *Queries) MarkFeedFetched(ctx context.Context, arg MarkFeedFetchedParams) error {
-	_, err := q.db.ExecContext(ctx, markFeedFetched, arg.UpdatedAt, arg.LastFetchedAt, arg.ID)
+	_, err := q.db.ExecContext(ctx, markFeedFetched,
+		arg.UpdatedAt,
+		arg.LastFetchedAt,
+		arg.Etag,
+		arg.LastModified,
+		arg.NextFetchAt,
+		arg.UpdateHintSeconds,
+		arg.SiteUrl,
+		arg.ID,
+	)
 	return err
 }
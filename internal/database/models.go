@@ -6,18 +6,43 @@ package database
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type ApiToken struct {
+	ID         int32
+	CreatedAt  time.Time
+	UserID     uuid.UUID
+	Name       string
+	TokenHash  string
+	LastUsedAt sql.NullTime
+	FeverKey   sql.NullString
+}
+
 type Feed struct {
-	ID            int32
-	CreatedAt     sql.NullTime
-	UpdatedAt     sql.NullTime
-	Name          sql.NullString
-	Url           sql.NullString
-	UserID        uuid.NullUUID
-	LastFetchedAt sql.NullTime
+	ID                     int32
+	CreatedAt              sql.NullTime
+	UpdatedAt              sql.NullTime
+	Name                   sql.NullString
+	Url                    sql.NullString
+	UserID                 uuid.NullUUID
+	LastFetchedAt          sql.NullTime
+	Etag                   sql.NullString
+	LastModified           sql.NullString
+	PreviousUrl            sql.NullString
+	NextFetchAt            sql.NullTime
+	FetchIntervalSeconds   sql.NullInt32
+	UpdateHintSeconds      sql.NullInt32
+	LastError              sql.NullString
+	ConsecutiveFailures    int32
+	LastSuccessAt          sql.NullTime
+	DisabledAt             sql.NullTime
+	SiteUrl                sql.NullString
+	RetentionMaxAgeSeconds sql.NullInt32
+	RetentionMaxPosts      sql.NullInt32
+	KeepForever            bool
 }
 
 type FeedFollow struct {
@@ -28,6 +53,42 @@ type FeedFollow struct {
 	FeedID    sql.NullInt32
 }
 
+type FetchLog struct {
+	ID         int32
+	FeedID     int32
+	StartedAt  time.Time
+	FinishedAt time.Time
+	StatusCode sql.NullInt32
+	Bytes      int32
+	ItemsSeen  int32
+	ItemsNew   int32
+	Error      sql.NullString
+}
+
+type Filter struct {
+	ID        int32
+	CreatedAt time.Time
+	UserID    uuid.UUID
+	FeedID    sql.NullInt32
+	Field     string
+	MatchType string
+	Pattern   string
+	Action    string
+}
+
+type Folder struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uuid.UUID
+	Name      string
+}
+
+type FolderFollow struct {
+	FolderID     int32
+	FeedFollowID int32
+}
+
 type Post struct {
 	ID          int32
 	CreatedAt   sql.NullTime
@@ -37,6 +98,40 @@ type Post struct {
 	Description sql.NullString
 	PublishedAt sql.NullTime
 	FeedID      int32
+	Guid        string
+	Search      interface{}
+	Author      sql.NullString
+}
+
+type PostRead struct {
+	UserID uuid.UUID
+	PostID int32
+	ReadAt time.Time
+}
+
+type PostStar struct {
+	ID          int32
+	CreatedAt   time.Time
+	UserID      uuid.UUID
+	PostID      sql.NullInt32
+	Title       string
+	Url         string
+	Description sql.NullString
+	PublishedAt sql.NullTime
+	FeedName    sql.NullString
+	FeedUrl     sql.NullString
+}
+
+type PrunedPost struct {
+	FeedID   int32
+	Guid     string
+	PrunedAt time.Time
+}
+
+type Retention struct {
+	ID            bool
+	MaxAgeSeconds sql.NullInt32
+	MaxPosts      sql.NullInt32
 }
 
 type User struct {
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"gator/internal/config"
 	"gator/internal/database"
 	"os"
+	"os/signal"
+	"syscall"
 
 	_ "github.com/lib/pq"
 )
@@ -27,8 +30,17 @@ func main() {
 
 	dbQueries := database.New(db)
 
+	// Cancel the root context on Ctrl-C or SIGTERM, a second signal kills the program
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	// Set the current state
 	State = state{
+		ctx: ctx,
 		db:  dbQueries,
 		cfg: &configObj,
 	}
@@ -38,17 +50,38 @@ func main() {
 	}
 
 	// register the commands
-	cmds.register("login", handlerLogin)                             // log in as existing user
-	cmds.register("register", handlerRegister)                       // register a new user
-	cmds.register("reset", handlerReset)                             // reset the database
-	cmds.register("users", handlerUsers)                             // get a list of users
-	cmds.register("agg", handlerAgg)                                 // scrape feeds at an interval
-	cmds.register("addfeed", middlewareLoggedIn(handlerAddFeed))     // add a feed
-	cmds.register("feeds", handlerFeeds)                             // get a list of feeds
-	cmds.register("follow", middlewareLoggedIn(handlerFollow))       // follow a feed
-	cmds.register("following", middlewareLoggedIn(handlerFollowing)) // get a list of followed feeds
-	cmds.register("unfollow", middlewareLoggedIn(handlerUnfollow))   // unfollow a feed
-	cmds.register("browse", middlewareLoggedIn(handlerBrowse))       // browse a number of posts
+	cmds.register("login", handlerLogin)                               // log in as existing user
+	cmds.register("register", handlerRegister)                         // register a new user
+	cmds.register("reset", handlerReset)                               // reset the database
+	cmds.register("users", handlerUsers)                               // get a list of users
+	cmds.register("agg", handlerAgg)                                   // scrape feeds at an interval
+	cmds.register("interval", handlerInterval)                         // set how often a feed is fetched
+	cmds.register("feedhealth", handlerFeedHealth)                     // list failing and disabled feeds
+	cmds.register("enablefeed", handlerEnableFeed)                     // re-enable a disabled feed
+	cmds.register("fetchlog", handlerFetchLog)                         // show recent fetch attempts
+	cmds.register("retention", handlerRetention)                       // show or set how long posts are kept
+	cmds.register("prune", handlerPrune)                               // remove posts over the retention limits
+	cmds.register("addfeed", middlewareLoggedIn(handlerAddFeed))       // add a feed
+	cmds.register("feeds", handlerFeeds)                               // get a list of feeds
+	cmds.register("follow", middlewareLoggedIn(handlerFollow))         // follow a feed
+	cmds.register("following", middlewareLoggedIn(handlerFollowing))   // get a list of followed feeds
+	cmds.register("unfollow", middlewareLoggedIn(handlerUnfollow))     // unfollow a feed
+	cmds.register("folder", middlewareLoggedIn(handlerFolder))         // manage folders of followed feeds
+	cmds.register("browse", middlewareLoggedIn(handlerBrowse))         // browse a number of posts
+	cmds.register("read", middlewareLoggedIn(handlerRead))             // read a post and mark it read
+	cmds.register("markread", middlewareLoggedIn(handlerMarkRead))     // mark posts read
+	cmds.register("markunread", middlewareLoggedIn(handlerMarkUnread)) // mark posts unread
+	cmds.register("star", middlewareLoggedIn(handlerStar))             // star posts to keep them
+	cmds.register("unstar", middlewareLoggedIn(handlerUnstar))         // remove stars
+	cmds.register("starred", middlewareLoggedIn(handlerStarred))       // list starred posts
+	cmds.register("search", middlewareLoggedIn(handlerSearch))         // search the followed feeds
+	cmds.register("filter", middlewareLoggedIn(handlerFilter))         // manage filter rules
+	cmds.register("import", middlewareLoggedIn(handlerImport))         // import and follow feeds from a file
+	cmds.register("export", middlewareLoggedIn(handlerExport))         // export followed feeds to a file
+	cmds.register("timeline", middlewareLoggedIn(handlerTimeline))     // write the timeline as a feed
+	cmds.register("token", middlewareLoggedIn(handlerToken))           // manage api tokens
+	cmds.register("serve", handlerServe)                               // serve the json api
+	cmds.register("web", middlewareLoggedIn(handlerWeb))               // serve the html reader
 
 	// If length of args is less than 2, then no command was given
 	if len(os.Args) < 2 {
@@ -62,7 +95,7 @@ func main() {
 	if err := cmds.run(&State, cmd); err != nil {
 		fmt.Println("Error executing command:", err)
 		os.Exit(1)
-	} else {
+	} else if !State.wroteDocument {
 		fmt.Println("Command executed successfully")
 	}
 }
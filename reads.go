@@ -0,0 +1,166 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+// print a post in full and mark it read
+func handlerRead(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("read command requires a post id")
+	}
+	postID, err := parsePostID(cmd.args[0])
+	if err != nil {
+		return err
+	}
+
+	// only posts of followed feeds can be read
+	post, err := s.db.GetPostForUser(s.ctx, database.GetPostForUserParams{
+		ID:     postID,
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("there is no post %d", postID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.MarkPostRead(s.ctx, database.MarkPostReadParams{
+		UserID: user.ID,
+		ReadAt: time.Now(),
+		PostID: post.ID,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println(post.Title)
+	fmt.Println("Feed:", post.FeedName.String)
+	if post.Author.Valid {
+		fmt.Println("Author:", post.Author.String)
+	}
+	fmt.Println("Published:", post.PublishedAt.Time)
+	fmt.Println("Link:", post.Url)
+	fmt.Println("")
+	fmt.Println(post.Description.String)
+	return nil
+}
+
+// mark posts read by id, every post of a feed with --feed, or every post with --all
+func handlerMarkRead(s *state, cmd command, user database.User) error {
+	fs := flag.NewFlagSet("markread", flag.ContinueOnError)
+	feedName := fs.String("feed", "", "mark every post of a feed, by url or name")
+	all := fs.Bool("all", false, "mark every post of every followed feed")
+	args, err := parseArgs(fs, cmd.args)
+	if err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	switch {
+	case *all:
+		marked, err := s.db.MarkAllRead(s.ctx, database.MarkAllReadParams{
+			UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+			ReadAt:    timeNow,
+			CreatedAt: sql.NullTime{Time: timeNow, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Marked %d posts read\n", marked)
+
+	case *feedName != "":
+		feed, err := getFeedByUrlOrName(s, *feedName)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.GetFeedFollow(s.ctx, database.GetFeedFollowParams{
+			UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+			FeedID: sql.NullInt32{Int32: feed.ID, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s does not follow %s", user.Name, feed.Name.String)
+		}
+		if err != nil {
+			return err
+		}
+		marked, err := s.db.MarkFeedRead(s.ctx, database.MarkFeedReadParams{
+			UserID:    user.ID,
+			ReadAt:    timeNow,
+			FeedID:    feed.ID,
+			CreatedAt: sql.NullTime{Time: timeNow, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Marked %d posts of %s read\n", marked, feed.Name.String)
+
+	// posts that were already read or do not exist are not counted
+	case len(args) > 0:
+		var marked int64
+		for _, arg := range args {
+			postID, err := parsePostID(arg)
+			if err != nil {
+				return err
+			}
+			n, err := s.db.MarkPostRead(s.ctx, database.MarkPostReadParams{
+				UserID: user.ID,
+				ReadAt: timeNow,
+				PostID: postID,
+			})
+			if err != nil {
+				return err
+			}
+			marked += n
+		}
+		fmt.Printf("Marked %d posts read\n", marked)
+
+	default:
+		return fmt.Errorf("markread command requires post ids, --feed {url or name} or --all")
+	}
+	return nil
+}
+
+// mark posts unread again
+func handlerMarkUnread(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("markunread command requires a post id")
+	}
+
+	// posts that were not read are not counted
+	var marked int64
+	for _, arg := range cmd.args {
+		postID, err := parsePostID(arg)
+		if err != nil {
+			return err
+		}
+		n, err := s.db.MarkPostUnread(s.ctx, database.MarkPostUnreadParams{
+			UserID: user.ID,
+			PostID: postID,
+		})
+		if err != nil {
+			return err
+		}
+		marked += n
+	}
+	fmt.Printf("Marked %d posts unread\n", marked)
+	return nil
+}
+
+// read a post id as shown by browse
+func parsePostID(arg string) (int32, error) {
+	id, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid post id %q", arg)
+	}
+	return int32(id), nil
+}
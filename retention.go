@@ -0,0 +1,259 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gator/internal/database"
+)
+
+// show or set how long posts are kept, globally or for one feed with --feed.
+// Posts over either limit are removed by prune, starred posts are always kept
+func handlerRetention(s *state, cmd command) error {
+	fs := flag.NewFlagSet("retention", flag.ContinueOnError)
+	feedName := fs.String("feed", "", "set the retention of one feed, by url or name")
+	maxAge := fs.String("max-age", "", "remove posts published longer ago than this, like 30d or 12h, or none")
+	maxPosts := fs.String("max-posts", "", "keep at most this many posts per feed, or none")
+	keepForever := fs.Bool("keep-forever", false, "never remove posts of the feed")
+	if _, err := parseArgs(fs, cmd.args); err != nil {
+		return err
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// show the current policy when nothing is set
+	if !set["max-age"] && !set["max-posts"] && !set["keep-forever"] {
+		return printRetention(s)
+	}
+
+	if *feedName == "" {
+		if set["keep-forever"] {
+			return fmt.Errorf("--keep-forever requires --feed")
+		}
+		params, err := s.db.GetRetention(s.ctx)
+		if err != nil {
+			return err
+		}
+		if set["max-age"] {
+			if params.MaxAgeSeconds, err = parseRetentionAge(*maxAge); err != nil {
+				return err
+			}
+		}
+		if set["max-posts"] {
+			if params.MaxPosts, err = parseRetentionPosts(*maxPosts); err != nil {
+				return err
+			}
+		}
+		if err := s.db.SetRetention(s.ctx, database.SetRetentionParams(params)); err != nil {
+			return err
+		}
+		return printRetention(s)
+	}
+
+	// limits of a feed that are set to none fall back to the global ones
+	feed, err := getFeedByUrlOrName(s, *feedName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("there is no feed %s", *feedName)
+	}
+	if err != nil {
+		return err
+	}
+	params := database.SetFeedRetentionParams{
+		UpdatedAt:              getNullTimeNow(),
+		RetentionMaxAgeSeconds: feed.RetentionMaxAgeSeconds,
+		RetentionMaxPosts:      feed.RetentionMaxPosts,
+		KeepForever:            feed.KeepForever,
+		ID:                     feed.ID,
+	}
+	if set["max-age"] {
+		if params.RetentionMaxAgeSeconds, err = parseRetentionAge(*maxAge); err != nil {
+			return err
+		}
+	}
+	if set["max-posts"] {
+		if params.RetentionMaxPosts, err = parseRetentionPosts(*maxPosts); err != nil {
+			return err
+		}
+	}
+	if set["keep-forever"] {
+		params.KeepForever = *keepForever
+	}
+	if err := s.db.SetFeedRetention(s.ctx, params); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %s\n", feed.Name.String, describeFeedRetention(params.RetentionMaxAgeSeconds, params.RetentionMaxPosts, params.KeepForever))
+	return nil
+}
+
+// print the global retention and the feeds with their own
+func printRetention(s *state) error {
+	global, err := globalRetention(s)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Global:", describeRetention(global.MaxAgeSeconds, global.MaxPosts))
+
+	feeds, err := s.db.GetFeeds(s.ctx)
+	if err != nil {
+		return err
+	}
+	for _, feed := range feeds {
+		if !feed.RetentionMaxAgeSeconds.Valid && !feed.RetentionMaxPosts.Valid && !feed.KeepForever {
+			continue
+		}
+		fmt.Printf("%s: %s\n", feed.Name.String, describeFeedRetention(feed.RetentionMaxAgeSeconds, feed.RetentionMaxPosts, feed.KeepForever))
+	}
+	return nil
+}
+
+// remove the posts that are over the retention limits, or only count them with --dry-run
+func handlerPrune(s *state, cmd command) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would be removed without removing it")
+	if _, err := parseArgs(fs, cmd.args); err != nil {
+		return err
+	}
+	return prunePosts(s, *dryRun)
+}
+
+// prune the posts and report how many were removed from each feed
+func prunePosts(s *state, dryRun bool) error {
+	params, err := globalRetention(s)
+	if err != nil {
+		return err
+	}
+	params.Now = time.Now()
+
+	var feeds []database.PrunePostsRow
+	if dryRun {
+		counts, err := s.db.CountPrunablePosts(s.ctx, database.CountPrunablePostsParams(params))
+		if err != nil {
+			return err
+		}
+		for _, count := range counts {
+			feeds = append(feeds, database.PrunePostsRow(count))
+		}
+	} else {
+		feeds, err = s.db.PrunePosts(s.ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	var total int64
+	for _, feed := range feeds {
+		total += feed.Posts
+		fmt.Printf(" - %s: %d posts\n", feed.FeedName.String, feed.Posts)
+	}
+	fmt.Printf("%s %d posts\n", verb, total)
+	return nil
+}
+
+// get the global retention, it is read on each prune so changes apply to running agg processes
+func globalRetention(s *state) (database.PrunePostsParams, error) {
+	retention, err := s.db.GetRetention(s.ctx)
+	if err != nil {
+		return database.PrunePostsParams{}, err
+	}
+	return database.PrunePostsParams{
+		MaxAgeSeconds: retention.MaxAgeSeconds,
+		MaxPosts:      retention.MaxPosts,
+	}, nil
+}
+
+// get the time before which items of a feed are not saved, because prune would
+// remove them again. Zero when the feed keeps its posts
+func retentionCutoff(s *state, feed database.Feed, now time.Time) (time.Time, error) {
+	if feed.KeepForever {
+		return time.Time{}, nil
+	}
+	maxAge := feed.RetentionMaxAgeSeconds
+	if !maxAge.Valid {
+		global, err := globalRetention(s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("reading the global retention: %w", err)
+		}
+		maxAge = global.MaxAgeSeconds
+	}
+	if !maxAge.Valid {
+		return time.Time{}, nil
+	}
+	return now.Add(-time.Duration(maxAge.Int32) * time.Second), nil
+}
+
+// parse a max age like 30d or 12h, none is no limit
+func parseRetentionAge(value string) (sql.NullInt32, error) {
+	if value == "none" {
+		return sql.NullInt32{}, nil
+	}
+
+	age, err := parseDuration(value)
+	if err != nil {
+		return sql.NullInt32{}, fmt.Errorf("invalid max age %q", value)
+	}
+	if age < time.Second || age.Seconds() > float64(1<<31-1) {
+		return sql.NullInt32{}, fmt.Errorf("max age %q is out of range", value)
+	}
+	return sql.NullInt32{Int32: int32(age.Seconds()), Valid: true}, nil
+}
+
+// parse a max number of posts, none is no limit
+func parseRetentionPosts(value string) (sql.NullInt32, error) {
+	if value == "none" {
+		return sql.NullInt32{}, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n < 1 {
+		return sql.NullInt32{}, fmt.Errorf("max posts must be a positive number or none")
+	}
+	return sql.NullInt32{Int32: int32(n), Valid: true}, nil
+}
+
+// format a max age in days when it is whole days
+func formatRetentionAge(seconds int32) string {
+	age := time.Duration(seconds) * time.Second
+	if age%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", age/(24*time.Hour))
+	}
+	return age.String()
+}
+
+// describe a pair of retention limits, empty when there are none
+func retentionLimits(maxAge, maxPosts sql.NullInt32) string {
+	var limits []string
+	if maxAge.Valid {
+		limits = append(limits, "posts older than "+formatRetentionAge(maxAge.Int32)+" are removed")
+	}
+	if maxPosts.Valid {
+		limits = append(limits, fmt.Sprintf("at most %d posts are kept", maxPosts.Int32))
+	}
+	return strings.Join(limits, ", ")
+}
+
+// describe the global retention
+func describeRetention(maxAge, maxPosts sql.NullInt32) string {
+	if limits := retentionLimits(maxAge, maxPosts); limits != "" {
+		return limits
+	}
+	return "every post is kept"
+}
+
+// describe the retention of a feed, unset limits are the global ones
+func describeFeedRetention(maxAge, maxPosts sql.NullInt32, keepForever bool) string {
+	if keepForever {
+		return "every post is kept"
+	}
+	if limits := retentionLimits(maxAge, maxPosts); limits != "" {
+		return limits + ", other limits are the global ones"
+	}
+	return "the global retention applies"
+}
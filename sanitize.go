@@ -0,0 +1,200 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// tags kept in sanitized html and the attributes each may carry
+var allowedTags = map[atom.Atom][]string{
+	atom.A:          {"href", "title"},
+	atom.Abbr:       {"title"},
+	atom.B:          nil,
+	atom.Blockquote: nil,
+	atom.Br:         nil,
+	atom.Caption:    nil,
+	atom.Code:       nil,
+	atom.Dd:         nil,
+	atom.Del:        nil,
+	atom.Div:        nil,
+	atom.Dl:         nil,
+	atom.Dt:         nil,
+	atom.Em:         nil,
+	atom.Figcaption: nil,
+	atom.Figure:     nil,
+	atom.H1:         nil,
+	atom.H2:         nil,
+	atom.H3:         nil,
+	atom.H4:         nil,
+	atom.H5:         nil,
+	atom.H6:         nil,
+	atom.Hr:         nil,
+	atom.I:          nil,
+	atom.Img:        {"src", "alt", "title", "width", "height"},
+	atom.Ins:        nil,
+	atom.Li:         nil,
+	atom.Ol:         nil,
+	atom.P:          nil,
+	atom.Pre:        nil,
+	atom.Q:          nil,
+	atom.S:          nil,
+	atom.Small:      nil,
+	atom.Span:       nil,
+	atom.Strong:     nil,
+	atom.Sub:        nil,
+	atom.Sup:        nil,
+	atom.Table:      nil,
+	atom.Tbody:      nil,
+	atom.Td:         {"colspan", "rowspan"},
+	atom.Tfoot:      nil,
+	atom.Th:         {"colspan", "rowspan"},
+	atom.Thead:      nil,
+	atom.Tr:         nil,
+	atom.U:          nil,
+	atom.Ul:         nil,
+}
+
+// tags that are removed together with everything inside them
+var droppedTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Math:     true,
+	atom.Form:     true,
+	atom.Select:   true,
+	atom.Textarea: true,
+	atom.Title:    true,
+	atom.Head:     true,
+}
+
+// void elements have no end tag
+var voidTags = map[atom.Atom]bool{
+	atom.Br:  true,
+	atom.Hr:  true,
+	atom.Img: true,
+}
+
+// reduce publisher html to a safe allowlist of tags and attributes.
+// Links and images are resolved against base and must be http(s), every
+// opened tag is closed so the description can not break the page around it
+func sanitizeHTML(description, base string) string {
+	baseURL, _ := url.Parse(base)
+	tokenizer := html.NewTokenizer(strings.NewReader(description))
+
+	var out strings.Builder
+	var open []atom.Atom
+	dropped := 0 // depth inside a dropped tag
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.TextToken:
+			if dropped == 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.DataAtom] {
+				if tokenType == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			attrs, ok := allowedTags[token.DataAtom]
+			if dropped > 0 || !ok {
+				continue
+			}
+			if token.DataAtom == atom.Img && safeURL(attrValue(token, "src"), baseURL) == "" {
+				continue
+			}
+
+			out.WriteString("<" + token.DataAtom.String())
+			for _, name := range attrs {
+				value := attrValue(token, name)
+				if name == "href" || name == "src" {
+					value = safeURL(value, baseURL)
+				}
+				if value == "" {
+					continue
+				}
+				out.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+			}
+			if token.DataAtom == atom.A {
+				out.WriteString(` rel="nofollow noopener noreferrer"`)
+			}
+			out.WriteString(">")
+
+			if !voidTags[token.DataAtom] {
+				open = append(open, token.DataAtom)
+			}
+
+		case html.EndTagToken:
+			if droppedTags[token.DataAtom] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+			if dropped > 0 {
+				continue
+			}
+			// close everything up to the matching open tag, ignore stray end tags
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != token.DataAtom {
+					continue
+				}
+				for len(open) > i {
+					out.WriteString("</" + open[len(open)-1].String() + ">")
+					open = open[:len(open)-1]
+				}
+				break
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i].String() + ">")
+	}
+	return out.String()
+}
+
+// get the value of an attribute of a token
+func attrValue(token html.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Namespace == "" && attr.Key == name {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
+
+// resolve a link against the base url, returning "" unless it is http(s) or mailto
+func safeURL(link string, base *url.URL) string {
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch u.Scheme {
+	case "http", "https", "mailto":
+		return u.String()
+	}
+	return ""
+}
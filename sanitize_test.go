@@ -0,0 +1,101 @@
+package main
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	const base = "https://example.com/blog/post"
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{
+			name:        "plain text is escaped",
+			description: "fish & chips",
+			want:        "fish &amp; chips",
+		},
+		{
+			name:        "script is dropped with its content",
+			description: `<p>before<script>alert("x")</script>after</p>`,
+			want:        "<p>beforeafter</p>",
+		},
+		{
+			name:        "nested dropped tags",
+			description: `<style><script>x</script>p { }</style>kept`,
+			want:        "kept",
+		},
+		{
+			name:        "event handler attributes are removed",
+			description: `<p onclick="alert(1)">hi</p><img src="/a.png" onerror="alert(1)">`,
+			want:        `<p>hi</p><img src="https://example.com/a.png">`,
+		},
+		{
+			name:        "javascript links lose their href",
+			description: `<a href="javascript:alert(1)">x</a>`,
+			want:        `<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:        "javascript links with mixed case and spaces",
+			description: `<a href=" JavaScript:alert(1)">x</a>`,
+			want:        `<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:        "data images are removed",
+			description: `<img src="data:image/png;base64,AAAA" alt="x">`,
+			want:        "",
+		},
+		{
+			name:        "data links lose their href",
+			description: `<a href="data:text/html,<script>alert(1)</script>">x</a>`,
+			want:        `<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:        "links get rel",
+			description: `<a href="https://other.example/" target="_blank">x</a>`,
+			want:        `<a href="https://other.example/" rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:        "publisher rel is replaced",
+			description: `<a href="https://other.example/" rel="opener">x</a>`,
+			want:        `<a href="https://other.example/" rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:        "relative links are resolved against the post",
+			description: `<a href="../about">x</a> <a href="/top">y</a> <img src="img/a.png">`,
+			want:        `<a href="https://example.com/about" rel="nofollow noopener noreferrer">x</a> <a href="https://example.com/top" rel="nofollow noopener noreferrer">y</a> <img src="https://example.com/blog/img/a.png">`,
+		},
+		{
+			name:        "mailto links are kept",
+			description: `<a href="mailto:someone@example.com">mail</a>`,
+			want:        `<a href="mailto:someone@example.com" rel="nofollow noopener noreferrer">mail</a>`,
+		},
+		{
+			name:        "unknown tags are unwrapped",
+			description: `<article><custom>text</custom></article>`,
+			want:        "text",
+		},
+		{
+			name:        "unclosed tags are closed",
+			description: `<div><p><b>bold`,
+			want:        "<div><p><b>bold</b></p></div>",
+		},
+		{
+			name:        "stray end tags are ignored",
+			description: `</div>text</p>`,
+			want:        "text",
+		},
+		{
+			name:        "attribute values are escaped",
+			description: `<img src="/a.png" alt="&quot;><script>alert(1)</script>">`,
+			want:        `<img src="https://example.com/a.png" alt="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeHTML(tt.description, base); got != tt.want {
+				t.Errorf("sanitizeHTML(%q)\n got %q\nwant %q", tt.description, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"database/sql"
+	"time"
+
+	"gator/internal/database"
+)
+
+const (
+	// how long a claimed feed is skipped by other workers while it is fetched
+	claimLease = 15 * time.Minute
+	// the longest a feed waits between fetches unless its interval is set by hand
+	maxFetchInterval = 24 * time.Hour
+	// how many recent posts are used to measure how often a feed posts
+	postingHistory = 10
+)
+
+// work out when a feed should be fetched next
+func nextFetchTime(s *state, feed database.Feed, hint, defaultInterval time.Duration) (time.Time, error) {
+	// an interval set by the user always wins
+	if feed.FetchIntervalSeconds.Valid {
+		return time.Now().Add(time.Duration(feed.FetchIntervalSeconds.Int32) * time.Second), nil
+	}
+
+	// get the publish times of the most recent posts
+	published, err := s.db.GetPublishTimesForFeed(s.ctx, database.GetPublishTimesForFeedParams{
+		FeedID: feed.ID,
+		Limit:  postingHistory,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Now().Add(adaptiveInterval(published, hint, defaultInterval)), nil
+}
+
+// poll twice per average gap between posts, never sooner than the publisher's
+// update hint or the default interval, and never later than the maximum
+func adaptiveInterval(published []sql.NullTime, hint, defaultInterval time.Duration) time.Duration {
+	interval := defaultInterval
+
+	// published is ordered newest first
+	if len(published) > 1 {
+		newest := published[0].Time
+		oldest := published[len(published)-1].Time
+		gap := newest.Sub(oldest) / time.Duration(len(published)-1)
+		interval = max(interval, gap/2)
+	}
+
+	interval = max(interval, hint)
+	return max(min(interval, maxFetchInterval), defaultInterval)
+}
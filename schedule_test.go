@@ -0,0 +1,46 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	// publish times newest first, gap apart
+	history := func(n int, gap time.Duration) []sql.NullTime {
+		var published []sql.NullTime
+		for i := 0; i < n; i++ {
+			published = append(published, sql.NullTime{Time: now.Add(-time.Duration(i) * gap), Valid: true})
+		}
+		return published
+	}
+
+	tests := []struct {
+		name      string
+		published []sql.NullTime
+		hint      time.Duration
+		want      time.Duration
+	}{
+		{"no history uses the default", nil, 0, time.Minute},
+		{"one post uses the default", history(1, time.Hour), 0, time.Minute},
+		{"half the average gap", history(10, 4*time.Hour), 0, 2 * time.Hour},
+		{"never sooner than the default", history(10, time.Minute), 0, time.Minute},
+		{"never later than the maximum", history(10, 7*24*time.Hour), 0, maxFetchInterval},
+		{"the hint wins over a shorter gap", history(10, time.Hour), 3 * time.Hour, 3 * time.Hour},
+		{"the hint is capped at the maximum", nil, 48 * time.Hour, maxFetchInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adaptiveInterval(tt.published, tt.hint, time.Minute); got != tt.want {
+				t.Errorf("adaptiveInterval = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// the maximum never overrides a longer default interval
+	if got := adaptiveInterval(nil, 0, 48*time.Hour); got != 48*time.Hour {
+		t.Errorf("adaptiveInterval with a 48h default = %s, want 48h", got)
+	}
+}
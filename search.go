@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+// search the posts of the followed feeds, best match first.
+// The query supports "quoted phrases", OR and -excluded words
+func handlerSearch(s *state, cmd command, user database.User) error {
+	limit, query, err := parseSearchArgs(cmd.args)
+	if err != nil {
+		return err
+	}
+
+	results, err := s.db.SearchPosts(s.ctx, database.SearchPostsParams{
+		Query:  query,
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No posts match %q\n", query)
+		return nil
+	}
+
+	// matches are marked with ** in the title and the excerpt
+	for _, result := range results {
+		fmt.Printf("-- Post %d, %s, %s\n", result.ID, result.FeedName.String, result.PublishedAt.Time.Format("2006-01-02"))
+		fmt.Println(result.TitleHeadline)
+		fmt.Println(result.Url)
+		if headline := strings.Join(strings.Fields(result.Headline), " "); headline != "" {
+			fmt.Println(headline)
+		}
+	}
+	return nil
+}
+
+// split the search arguments into the count and the query. Only a leading -n is
+// a flag, the rest is the query as given so -excluded words reach postgres
+func parseSearchArgs(args []string) (int, string, error) {
+	limit := 10
+	if len(args) > 0 {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		if strings.HasPrefix(args[0], "-") && name == "n" {
+			args = args[1:]
+			if !hasValue {
+				if len(args) == 0 {
+					return 0, "", fmt.Errorf("search: -n requires a number")
+				}
+				value, args = args[0], args[1:]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return 0, "", fmt.Errorf("the number of results must be a positive number")
+			}
+			limit = n
+		}
+	}
+	if len(args) < 1 {
+		return 0, "", fmt.Errorf("search command requires a query")
+	}
+	return limit, strings.Join(args, " "), nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestParseSearchArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantLimit int
+		wantQuery string
+		wantErr   bool
+	}{
+		{"query only", []string{"rust"}, 10, "rust", false},
+		{"excluded word", []string{"rust", "-async"}, 10, "rust -async", false},
+		{"count then excluded word", []string{"-n", "5", "rust", "-async"}, 5, "rust -async", false},
+		{"count with equals", []string{"--n=3", `"borrow checker"`, "OR", "lifetimes"}, 3, `"borrow checker" OR lifetimes`, false},
+		{"count after the query is part of it", []string{"rust", "-n", "5"}, 10, "rust -n 5", false},
+		{"no query", []string{"-n", "5"}, 0, "", true},
+		{"missing count", []string{"-n"}, 0, "", true},
+		{"zero count", []string{"-n", "0", "rust"}, 0, "", true},
+		{"negative count", []string{"-n", "-1", "rust"}, 0, "", true},
+		{"nothing", nil, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, query, err := parseSearchArgs(tt.args)
+			if (err != nil) != tt.wantErr || limit != tt.wantLimit || query != tt.wantQuery {
+				t.Errorf("parseSearchArgs(%q) = %d, %q, %v; want %d, %q, error %v", tt.args, limit, query, err, tt.wantLimit, tt.wantQuery, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+-- name: AddFeedFollowToFolder :execrows
+INSERT INTO folder_follows (folder_id, feed_follow_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: CheckFilterRegex :one
+SELECT '' ~* sqlc.arg(pattern)::text AS matches;
\ No newline at end of file
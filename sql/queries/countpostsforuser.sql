@@ -0,0 +1,5 @@
+-- name: CountPostsForUser :one
+SELECT COUNT(*) FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = $1;
\ No newline at end of file
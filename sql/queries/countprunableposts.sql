@@ -0,0 +1,22 @@
+-- name: CountPrunablePosts :many
+WITH ranked AS (
+    SELECT posts.id, posts.feed_id, posts.published_at,
+        row_number() OVER (PARTITION BY posts.feed_id ORDER BY posts.published_at DESC, posts.id DESC) AS position,
+        COALESCE(feed.retention_max_age_seconds, sqlc.narg(max_age_seconds)::int) AS max_age_seconds,
+        COALESCE(feed.retention_max_posts, sqlc.narg(max_posts)::int) AS max_posts
+    FROM posts
+    INNER JOIN feed
+    ON posts.feed_id = feed.id
+    WHERE NOT feed.keep_forever
+), prunable AS (
+    SELECT ranked.id, ranked.feed_id FROM ranked
+    WHERE (ranked.published_at < sqlc.arg(now)::timestamp - make_interval(secs => ranked.max_age_seconds)
+        OR ranked.position > ranked.max_posts)
+        AND NOT EXISTS (SELECT 1 FROM post_stars WHERE post_stars.post_id = ranked.id)
+)
+SELECT feed.id AS feed_id, feed.name AS feed_name, COUNT(*) AS posts
+FROM prunable
+INNER JOIN feed
+ON prunable.feed_id = feed.id
+GROUP BY feed.id
+ORDER BY feed.name;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: CreateApiToken :one
+INSERT INTO api_tokens (created_at, user_id, name, token_hash, fever_key)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
\ No newline at end of file
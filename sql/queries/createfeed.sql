@@ -1,10 +1,11 @@
 -- name: CreateFeed :exec
-INSERT INTO feed(created_at, updated_at, name, url, user_id)
+INSERT INTO feed(created_at, updated_at, name, url, user_id, site_url)
 VALUES(
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
 RETURNING *;
\ No newline at end of file
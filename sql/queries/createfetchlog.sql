@@ -0,0 +1,3 @@
+-- name: CreateFetchLog :exec
+INSERT INTO fetch_log(feed_id, started_at, finished_at, status_code, bytes, items_seen, items_new, error)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: CreateFilter :one
+INSERT INTO filters (created_at, user_id, feed_id, field, match_type, pattern, action)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
\ No newline at end of file
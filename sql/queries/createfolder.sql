@@ -0,0 +1,4 @@
+-- name: CreateFolder :one
+INSERT INTO folders (created_at, updated_at, user_id, name)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
\ No newline at end of file
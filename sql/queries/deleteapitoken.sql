@@ -0,0 +1,3 @@
+-- name: DeleteApiToken :execrows
+DELETE FROM api_tokens
+WHERE id = $1 AND user_id = $2;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: DeleteFilter :execrows
+DELETE FROM filters WHERE id = $1 AND user_id = $2;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: DeleteFolder :execrows
+DELETE FROM folders WHERE user_id = $1 AND (name = $2 OR starts_with(name, $2 || '/'));
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: DeleteStar :execrows
+DELETE FROM post_stars
+WHERE id = $1 AND user_id = $2;
\ No newline at end of file
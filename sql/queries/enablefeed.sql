@@ -0,0 +1,4 @@
+-- name: EnableFeed :exec
+UPDATE feed
+SET updated_at=$1, disabled_at=NULL, consecutive_failures=0, next_fetch_at=NULL
+WHERE id=$2;
\ No newline at end of file
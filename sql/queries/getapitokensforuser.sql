@@ -0,0 +1,4 @@
+-- name: GetApiTokensForUser :many
+SELECT * FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- name: GetFailingFeeds :many
+SELECT *
+FROM feed
+WHERE consecutive_failures > 0 OR disabled_at IS NOT NULL
+ORDER BY disabled_at NULLS LAST, consecutive_failures DESC;
\ No newline at end of file
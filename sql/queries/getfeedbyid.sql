@@ -0,0 +1,2 @@
+-- name: GetFeedById :one
+SELECT * FROM feed WHERE id = $1;
\ No newline at end of file
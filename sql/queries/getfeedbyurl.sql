@@ -1,2 +1,5 @@
 -- name: GetFeedByUrl :one
-SELECT * FROM feed WHERE url = $1;
\ No newline at end of file
+SELECT * FROM feed
+WHERE url = $1 OR previous_url = $1
+ORDER BY url = $1 DESC
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: GetFeedFollow :one
+SELECT * FROM feed_follow WHERE user_id = $1 AND feed_id = $2;
\ No newline at end of file
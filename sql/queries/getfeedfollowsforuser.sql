@@ -1,8 +1,16 @@
 -- name: GetFeedFollowsForUser :many
-SELECT feed.name AS feed_name, users.name AS user_name
+SELECT feed.name AS feed_name, users.name AS user_name, feed.url AS feed_url, feed.site_url, feed.id AS feed_id, feed.last_fetched_at,
+    feed_follow.id AS feed_follow_id,
+    COALESCE(array_agg(folders.name ORDER BY folders.name) FILTER (WHERE folders.name IS NOT NULL), '{}')::text[] AS folders
 FROM feed_follow
 INNER JOIN users
 ON feed_follow.user_id = users.id 
 INNER JOIN feed
 ON feed_follow.feed_id = feed.id
-WHERE users.name = $1;
\ No newline at end of file
+LEFT JOIN folder_follows
+ON folder_follows.feed_follow_id = feed_follow.id
+LEFT JOIN folders
+ON folder_follows.folder_id = folders.id
+WHERE users.name = $1
+GROUP BY feed_follow.id, feed.id, users.name
+ORDER BY feed.name;
\ No newline at end of file
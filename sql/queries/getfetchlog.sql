@@ -0,0 +1,7 @@
+-- name: GetFetchLog :many
+SELECT fetch_log.*, feed.name AS feed_name
+FROM fetch_log
+INNER JOIN feed
+ON fetch_log.feed_id = feed.id
+ORDER BY fetch_log.started_at DESC
+LIMIT $1;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- name: GetFetchLogForFeed :many
+SELECT fetch_log.*, feed.name AS feed_name
+FROM fetch_log
+INNER JOIN feed
+ON fetch_log.feed_id = feed.id
+WHERE fetch_log.feed_id = $1
+ORDER BY fetch_log.started_at DESC
+LIMIT $2;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- name: GetFeverItemsByIds :many
+SELECT posts.id, posts.feed_id, posts.title, posts.url, posts.description, posts.published_at, posts.created_at,
+    EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id) AS is_read,
+    EXISTS (SELECT 1 FROM post_stars WHERE post_stars.user_id = feed_follow.user_id AND post_stars.post_id = posts.id) AS is_saved
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = sqlc.arg(user_id) AND posts.id = ANY(sqlc.arg(ids)::int[])
+ORDER BY posts.id;
\ No newline at end of file
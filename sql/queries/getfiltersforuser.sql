@@ -0,0 +1,7 @@
+-- name: GetFiltersForUser :many
+SELECT filters.*, feed.name AS feed_name
+FROM filters
+LEFT JOIN feed
+ON filters.feed_id = feed.id
+WHERE filters.user_id = $1
+ORDER BY filters.id;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: GetFolderByName :one
+SELECT * FROM folders WHERE user_id = $1 AND name = $2;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- name: GetFolderFeedsForUser :many
+SELECT DISTINCT folders.id AS folder_id, feed_follow.feed_id
+FROM folders
+INNER JOIN folders AS subfolders
+ON subfolders.user_id = folders.user_id AND (subfolders.name = folders.name OR starts_with(subfolders.name, folders.name || '/'))
+INNER JOIN folder_follows
+ON folder_follows.folder_id = subfolders.id
+INNER JOIN feed_follow
+ON folder_follows.feed_follow_id = feed_follow.id
+WHERE feed_follow.user_id = $1
+ORDER BY folders.id, feed_follow.feed_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetFoldersForUser :many
+SELECT * FROM folders
+WHERE user_id = $1
+ORDER BY name;
\ No newline at end of file
@@ -1,6 +1,14 @@
 -- name: GetNextFeedToFetch :one
-SELECT *
-FROM feed
-ORDER BY last_fetched_at
-NULLS FIRST
-LIMIT 1;
\ No newline at end of file
+UPDATE feed
+SET last_fetched_at = sqlc.arg(now), next_fetch_at = sqlc.arg(lease_until)
+WHERE id = (
+    SELECT id
+    FROM feed
+    WHERE disabled_at IS NULL
+        AND (next_fetch_at IS NULL OR next_fetch_at <= sqlc.arg(now))
+    ORDER BY next_fetch_at
+    NULLS FIRST
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: GetPost :one
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.guid, posts.author, feed.name AS feed_name, feed.url AS feed_url, feed.site_url AS feed_site_url
+FROM posts
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE posts.id = $1;
\ No newline at end of file
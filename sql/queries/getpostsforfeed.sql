@@ -0,0 +1,5 @@
+-- name: GetPostsForFeed :many
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, guid FROM posts
+WHERE feed_id = $1
+ORDER BY published_at DESC, id DESC
+LIMIT $2 OFFSET $3;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- name: GetPostsForUserAsc :many
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.guid, posts.author, feed.name AS feed_name, feed.url AS feed_url, feed.site_url AS feed_site_url,
+    EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id) AS is_read,
+    EXISTS (SELECT 1 FROM filters WHERE filters.user_id = feed_follow.user_id AND (filters.feed_id IS NULL OR filters.feed_id = posts.feed_id) AND filters.action = 'highlight' AND filter_matches(filters.field, filters.match_type, filters.pattern, posts.title, posts.description, posts.url, posts.author)) AS is_highlighted
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(feed_id)::int IS NULL OR posts.feed_id = sqlc.narg(feed_id))
+    AND (sqlc.narg(folder)::text IS NULL OR EXISTS (SELECT 1 FROM folder_follows INNER JOIN folders ON folder_follows.folder_id = folders.id WHERE folder_follows.feed_follow_id = feed_follow.id AND (folders.name = sqlc.narg(folder) OR starts_with(folders.name, sqlc.narg(folder) || '/'))))
+    AND (sqlc.narg(since)::timestamp IS NULL OR posts.published_at >= sqlc.narg(since))
+    AND (sqlc.narg(before_published_at)::timestamp IS NULL OR (posts.published_at, posts.id) < (sqlc.narg(before_published_at), sqlc.narg(before_id)::int))
+    AND (sqlc.narg(after_published_at)::timestamp IS NULL OR (posts.published_at, posts.id) > (sqlc.narg(after_published_at), sqlc.narg(after_id)::int))
+    AND (NOT sqlc.arg(unread_only)::bool OR NOT EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id))
+    AND (sqlc.arg(show_hidden)::bool OR NOT EXISTS (SELECT 1 FROM filters WHERE filters.user_id = feed_follow.user_id AND (filters.feed_id IS NULL OR filters.feed_id = posts.feed_id) AND filters.action IN ('hide', 'drop') AND filter_matches(filters.field, filters.match_type, filters.pattern, posts.title, posts.description, posts.url, posts.author)))
+ORDER BY posts.published_at ASC, posts.id ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
\ No newline at end of file
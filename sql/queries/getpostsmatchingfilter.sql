@@ -0,0 +1,12 @@
+-- name: GetPostsMatchingFilter :many
+SELECT posts.id, posts.title, posts.published_at, feed.name AS feed_name
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(feed_id)::int IS NULL OR posts.feed_id = sqlc.narg(feed_id))
+    AND filter_matches(sqlc.arg(field), sqlc.arg(match_type), sqlc.arg(pattern), posts.title, posts.description, posts.url, posts.author)
+ORDER BY posts.published_at DESC, posts.id DESC
+LIMIT sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: GetPublishTimesForFeed :many
+SELECT published_at
+FROM posts
+WHERE feed_id = $1 AND published_at IS NOT NULL
+ORDER BY published_at DESC
+LIMIT $2;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- name: GetReaderItems :many
+SELECT posts.id, posts.feed_id, posts.title, posts.url, posts.description, posts.published_at, posts.created_at,
+    feed.name AS feed_name, feed.site_url AS feed_site_url,
+    EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id) AS is_read,
+    EXISTS (SELECT 1 FROM post_stars WHERE post_stars.user_id = feed_follow.user_id AND post_stars.post_id = posts.id) AS is_starred
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(feed_id)::int IS NULL OR posts.feed_id = sqlc.narg(feed_id))
+    AND (sqlc.narg(folder)::text IS NULL OR EXISTS (SELECT 1 FROM folder_follows INNER JOIN folders ON folder_follows.folder_id = folders.id WHERE folder_follows.feed_follow_id = feed_follow.id AND (folders.name = sqlc.narg(folder) OR starts_with(folders.name, sqlc.narg(folder) || '/'))))
+    AND (NOT sqlc.arg(starred_only)::bool OR EXISTS (SELECT 1 FROM post_stars WHERE post_stars.user_id = feed_follow.user_id AND post_stars.post_id = posts.id))
+    AND (NOT sqlc.arg(unread_only)::bool OR NOT EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id))
+    AND (NOT sqlc.arg(read_only)::bool OR EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id))
+    AND (sqlc.narg(newer_than)::timestamp IS NULL OR posts.created_at > sqlc.narg(newer_than))
+    AND (sqlc.narg(older_than)::timestamp IS NULL OR posts.created_at < sqlc.narg(older_than))
+    AND posts.id > sqlc.arg(after_id)
+    AND posts.id < sqlc.arg(before_id)
+ORDER BY CASE WHEN sqlc.arg(oldest_first)::bool THEN posts.id END, posts.id DESC
+LIMIT sqlc.arg(max_items);
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- name: GetReaderItemsByIds :many
+SELECT posts.id, posts.feed_id, posts.title, posts.url, posts.description, posts.published_at, posts.created_at,
+    feed.name AS feed_name, feed.site_url AS feed_site_url,
+    EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id) AS is_read,
+    EXISTS (SELECT 1 FROM post_stars WHERE post_stars.user_id = feed_follow.user_id AND post_stars.post_id = posts.id) AS is_starred
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = sqlc.arg(user_id) AND posts.id = ANY(sqlc.arg(ids)::int[])
+ORDER BY posts.id DESC;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: GetRetention :one
+SELECT max_age_seconds, max_posts FROM retention;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetStarredPostIds :many
+SELECT post_id FROM post_stars
+WHERE user_id = $1 AND post_id IS NOT NULL
+ORDER BY post_id;
\ No newline at end of file
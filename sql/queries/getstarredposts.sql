@@ -0,0 +1,5 @@
+-- name: GetStarredPosts :many
+SELECT * FROM post_stars
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
\ No newline at end of file
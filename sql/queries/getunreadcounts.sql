@@ -0,0 +1,9 @@
+-- name: GetUnreadCounts :many
+SELECT posts.feed_id, COUNT(*) AS unread, MAX(posts.created_at)::timestamp AS newest
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = $1
+    AND NOT EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id)
+GROUP BY posts.feed_id
+ORDER BY posts.feed_id;
\ No newline at end of file
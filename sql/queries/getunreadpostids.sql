@@ -0,0 +1,7 @@
+-- name: GetUnreadPostIds :many
+SELECT posts.id FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = $1
+    AND NOT EXISTS (SELECT 1 FROM post_reads WHERE post_reads.user_id = feed_follow.user_id AND post_reads.post_id = posts.id)
+ORDER BY posts.id;
\ No newline at end of file
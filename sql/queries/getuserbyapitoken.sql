@@ -0,0 +1,8 @@
+-- name: GetUserByApiToken :one
+WITH used_token AS (
+    UPDATE api_tokens SET last_used_at = $1
+    WHERE token_hash = $2
+    RETURNING user_id
+)
+SELECT users.* FROM users
+INNER JOIN used_token ON used_token.user_id = users.id;
\ No newline at end of file
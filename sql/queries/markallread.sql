@@ -0,0 +1,7 @@
+-- name: MarkAllRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT feed_follow.user_id, posts.id, $2 FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = $1 AND posts.created_at <= $3
+ON CONFLICT DO NOTHING;
\ No newline at end of file
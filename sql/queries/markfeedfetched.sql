@@ -1,4 +1,5 @@
 -- name: MarkFeedFetched :exec
 UPDATE feed
-SET updated_at=$1, last_fetched_at=$2
-WHERE id=$3;
\ No newline at end of file
+SET updated_at=$1, last_fetched_at=$2, etag=$3, last_modified=$4, next_fetch_at=$5, update_hint_seconds=$6,
+    last_success_at=$2, consecutive_failures=0, last_error=NULL, site_url=$7
+WHERE id=$8;
\ No newline at end of file
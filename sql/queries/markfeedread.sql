@@ -0,0 +1,6 @@
+-- name: MarkFeedRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT $1, posts.id, $2 FROM posts
+INNER JOIN feed_follow ON posts.feed_id = feed_follow.feed_id
+WHERE posts.feed_id = $3 AND posts.created_at <= $4 AND feed_follow.user_id = $1
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- name: MarkFolderRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT feed_follow.user_id, posts.id, $2 FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+WHERE feed_follow.user_id = $1 AND posts.created_at <= $4
+    AND EXISTS (SELECT 1 FROM folder_follows
+        INNER JOIN folders ON folder_follows.folder_id = folders.id
+        INNER JOIN folders AS marked ON marked.id = $3 AND marked.user_id = feed_follow.user_id
+        WHERE folder_follows.feed_follow_id = feed_follow.id
+            AND (folders.name = marked.name OR starts_with(folders.name, marked.name || '/')))
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: MarkPostRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT $1, posts.id, $2 FROM posts
+INNER JOIN feed_follow ON posts.feed_id = feed_follow.feed_id
+WHERE posts.id = $3 AND feed_follow.user_id = $1
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: MarkPostUnread :execrows
+DELETE FROM post_reads
+WHERE user_id = $1 AND post_id = $2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: RecordFeedFailure :exec
+UPDATE feed
+SET updated_at=$1, last_fetched_at=$2, next_fetch_at=$3, last_error=$4, consecutive_failures=$5, disabled_at=$6
+WHERE id=$7;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: ReleaseFeed :exec
+UPDATE feed
+SET next_fetch_at=NULL
+WHERE id=$1;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: RemoveFeedFollowFromFolder :execrows
+DELETE FROM folder_follows
+WHERE folder_id = $1 AND feed_follow_id = $2;
\ No newline at end of file
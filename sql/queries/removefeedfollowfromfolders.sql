@@ -0,0 +1,2 @@
+-- name: RemoveFeedFollowFromFolders :exec
+DELETE FROM folder_follows WHERE feed_follow_id = $1;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: RenameFolder :execrows
+UPDATE folders SET updated_at = sqlc.arg(updated_at), name = sqlc.arg(new_name)::text || substr(name, length(sqlc.arg(old_name)::text) + 1)
+WHERE user_id = sqlc.arg(user_id) AND (name = sqlc.arg(old_name) OR starts_with(name, sqlc.arg(old_name) || '/'));
\ No newline at end of file
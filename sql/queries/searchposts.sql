@@ -0,0 +1,15 @@
+-- name: SearchPosts :many
+SELECT posts.id, posts.title, posts.url, posts.published_at, posts.feed_id, feed.name AS feed_name,
+    ts_headline('english', posts.title, websearch_to_tsquery('english', sqlc.arg(query)),
+        'HighlightAll=true, StartSel=**, StopSel=**')::text AS title_headline,
+    ts_headline('english', regexp_replace(coalesce(posts.description, ''), '<[^>]*>', ' ', 'g'), websearch_to_tsquery('english', sqlc.arg(query)),
+        'MaxFragments=2, MaxWords=20, MinWords=8, StartSel=**, StopSel=**')::text AS headline
+FROM posts
+INNER JOIN feed_follow
+ON posts.feed_id = feed_follow.feed_id
+INNER JOIN feed
+ON posts.feed_id = feed.id
+WHERE feed_follow.user_id = sqlc.arg(user_id)
+    AND posts.search @@ websearch_to_tsquery('english', sqlc.arg(query))
+ORDER BY ts_rank_cd(posts.search, websearch_to_tsquery('english', sqlc.arg(query))) DESC, posts.published_at DESC
+LIMIT sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: SetFeedFetchInterval :exec
+UPDATE feed
+SET updated_at=$1, fetch_interval_seconds=$2, next_fetch_at=NULL
+WHERE id=$3;
\ No newline at end of file
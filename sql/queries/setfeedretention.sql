@@ -0,0 +1,4 @@
+-- name: SetFeedRetention :exec
+UPDATE feed
+SET updated_at=$1, retention_max_age_seconds=$2, retention_max_posts=$3, keep_forever=$4
+WHERE id=$5;
\ No newline at end of file
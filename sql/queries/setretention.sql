@@ -0,0 +1,2 @@
+-- name: SetRetention :exec
+UPDATE retention SET max_age_seconds = $1, max_posts = $2;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- name: StarPost :execrows
+INSERT INTO post_stars (created_at, user_id, post_id, title, url, description, published_at, feed_name, feed_url)
+SELECT $1, $2, posts.id, posts.title, posts.url, posts.description, posts.published_at, feed.name, feed.url
+FROM posts
+INNER JOIN feed ON posts.feed_id = feed.id
+INNER JOIN feed_follow ON posts.feed_id = feed_follow.feed_id
+WHERE posts.id = $3 AND feed_follow.user_id = $2
+ON CONFLICT DO NOTHING;
\ No newline at end of file
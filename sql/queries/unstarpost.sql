@@ -0,0 +1,3 @@
+-- name: UnstarPost :execrows
+DELETE FROM post_stars
+WHERE user_id = $1 AND post_id = $2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: UpdateFeedUrl :exec
+UPDATE feed
+SET updated_at=$1, url=$2, previous_url=url
+WHERE id=$3;
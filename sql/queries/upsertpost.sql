@@ -0,0 +1,44 @@
+-- name: UpsertPost :one
+WITH item AS (
+    SELECT sqlc.narg(created_at)::timestamp AS created_at,
+        sqlc.narg(updated_at)::timestamp AS updated_at,
+        sqlc.arg(title)::text AS title,
+        sqlc.arg(url)::text AS url,
+        sqlc.narg(description)::text AS description,
+        sqlc.narg(published_at)::timestamp AS published_at,
+        sqlc.arg(feed_id)::int AS feed_id,
+        sqlc.arg(guid)::text AS guid,
+        sqlc.narg(author)::text AS author
+), new_item AS (
+    SELECT EXISTS (SELECT 1 FROM pruned_posts WHERE pruned_posts.feed_id = item.feed_id AND pruned_posts.guid = item.guid) AS pruned,
+        NOT EXISTS (SELECT 1 FROM posts WHERE posts.feed_id = item.feed_id AND posts.guid = item.guid)
+        AND (SELECT COUNT(*) > 0 AND bool_and(EXISTS (
+                SELECT 1 FROM filters
+                WHERE filters.user_id = feed_follow.user_id
+                    AND filters.action = 'drop'
+                    AND (filters.feed_id IS NULL OR filters.feed_id = feed_follow.feed_id)
+                    AND filter_matches(filters.field, filters.match_type, filters.pattern, item.title, item.description, item.url, item.author)
+            ))
+            FROM feed_follow
+            WHERE feed_follow.feed_id = item.feed_id) AS dropped
+    FROM item
+), saved AS (
+    INSERT INTO posts(created_at, updated_at, title, url, description, published_at, feed_id, guid, author)
+    SELECT item.created_at, item.updated_at, item.title, item.url, item.description, item.published_at, item.feed_id, item.guid, item.author
+    FROM item, new_item
+    WHERE NOT new_item.pruned AND NOT new_item.dropped
+    ON CONFLICT (feed_id, guid) DO UPDATE
+    SET updated_at = EXCLUDED.updated_at,
+        title = EXCLUDED.title,
+        url = EXCLUDED.url,
+        description = EXCLUDED.description,
+        author = EXCLUDED.author
+    WHERE posts.title IS DISTINCT FROM EXCLUDED.title
+        OR posts.url IS DISTINCT FROM EXCLUDED.url
+        OR posts.description IS DISTINCT FROM EXCLUDED.description
+        OR (posts.author IS NOT NULL AND posts.author IS DISTINCT FROM EXCLUDED.author)
+    RETURNING id, (xmax = 0) AS inserted
+)
+SELECT saved.id, saved.inserted, new_item.pruned, new_item.dropped
+FROM new_item
+LEFT JOIN saved ON true;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE feed ADD etag TEXT;
+ALTER TABLE feed ADD last_modified TEXT;
+
+-- +goose Down
+ALTER TABLE feed DROP etag;
+ALTER TABLE feed DROP last_modified;
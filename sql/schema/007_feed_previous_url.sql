@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feed ADD previous_url TEXT;
+
+-- +goose Down
+ALTER TABLE feed DROP previous_url;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE feed ADD next_fetch_at TIMESTAMP;
+ALTER TABLE feed ADD fetch_interval_seconds INTEGER;
+ALTER TABLE feed ADD update_hint_seconds INTEGER;
+
+-- +goose Down
+ALTER TABLE feed DROP next_fetch_at;
+ALTER TABLE feed DROP fetch_interval_seconds;
+ALTER TABLE feed DROP update_hint_seconds;
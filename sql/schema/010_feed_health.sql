@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE feed ADD last_error TEXT;
+ALTER TABLE feed ADD consecutive_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE feed ADD last_success_at TIMESTAMP;
+ALTER TABLE feed ADD disabled_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE feed DROP last_error;
+ALTER TABLE feed DROP consecutive_failures;
+ALTER TABLE feed DROP last_success_at;
+ALTER TABLE feed DROP disabled_at;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE fetch_log(
+    id SERIAL PRIMARY KEY,
+    feed_id INTEGER NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL,
+    status_code INTEGER,
+    bytes INTEGER NOT NULL,
+    items_seen INTEGER NOT NULL,
+    items_new INTEGER NOT NULL,
+    error TEXT,
+    CONSTRAINT fk_feed_id
+    FOREIGN KEY (feed_id)
+    REFERENCES feed(id) ON DELETE CASCADE
+);
+CREATE INDEX fetch_log_feed_id_started_at_idx ON fetch_log (feed_id, started_at);
+
+-- +goose Down
+DROP TABLE fetch_log;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE feed ADD site_url TEXT;
+ALTER TABLE feed_follow ADD category TEXT;
+
+-- +goose Down
+ALTER TABLE feed DROP site_url;
+ALTER TABLE feed_follow DROP category;
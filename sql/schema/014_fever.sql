@@ -0,0 +1,36 @@
+-- +goose Up
+ALTER TABLE api_tokens ADD fever_key TEXT UNIQUE;
+
+-- the read state of each user, also used by markread, markunread and browse --unread
+CREATE TABLE post_reads(
+    user_id UUID NOT NULL,
+    post_id INTEGER NOT NULL,
+    read_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, post_id),
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_post_id
+    FOREIGN KEY (post_id)
+    REFERENCES posts(id) ON DELETE CASCADE
+);
+
+-- the saved posts of each user, also used by star, unstar and starred
+CREATE TABLE post_stars(
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    post_id INTEGER NOT NULL,
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_post_id
+    FOREIGN KEY (post_id)
+    REFERENCES posts(id) ON DELETE CASCADE,
+    UNIQUE (user_id, post_id)
+);
+
+-- +goose Down
+DROP TABLE post_stars;
+DROP TABLE post_reads;
+ALTER TABLE api_tokens DROP fever_key;
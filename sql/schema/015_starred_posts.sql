@@ -0,0 +1,42 @@
+-- +goose Up
+-- starred posts keep a copy of the post so they outlive the feed
+ALTER TABLE post_stars
+    ADD title TEXT NOT NULL DEFAULT '',
+    ADD url TEXT NOT NULL DEFAULT '',
+    ADD description TEXT,
+    ADD published_at TIMESTAMP,
+    ADD feed_name TEXT,
+    ADD feed_url TEXT;
+UPDATE post_stars
+SET title = posts.title,
+    url = posts.url,
+    description = posts.description,
+    published_at = posts.published_at,
+    feed_name = feed.name,
+    feed_url = feed.url
+FROM posts
+INNER JOIN feed ON posts.feed_id = feed.id
+WHERE posts.id = post_stars.post_id;
+ALTER TABLE post_stars ALTER title DROP DEFAULT;
+ALTER TABLE post_stars ALTER url DROP DEFAULT;
+
+ALTER TABLE post_stars ALTER post_id DROP NOT NULL;
+ALTER TABLE post_stars DROP CONSTRAINT fk_post_id;
+ALTER TABLE post_stars ADD CONSTRAINT fk_post_id
+    FOREIGN KEY (post_id)
+    REFERENCES posts(id) ON DELETE SET NULL;
+
+-- +goose Down
+DELETE FROM post_stars WHERE post_id IS NULL;
+ALTER TABLE post_stars DROP CONSTRAINT fk_post_id;
+ALTER TABLE post_stars ADD CONSTRAINT fk_post_id
+    FOREIGN KEY (post_id)
+    REFERENCES posts(id) ON DELETE CASCADE;
+ALTER TABLE post_stars ALTER post_id SET NOT NULL;
+ALTER TABLE post_stars
+    DROP title,
+    DROP url,
+    DROP description,
+    DROP published_at,
+    DROP feed_name,
+    DROP feed_url;
@@ -0,0 +1,48 @@
+-- +goose Up
+CREATE TABLE folders(
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE folder_follows(
+    folder_id INTEGER NOT NULL,
+    feed_follow_id INTEGER NOT NULL,
+    PRIMARY KEY (folder_id, feed_follow_id),
+    CONSTRAINT fk_folder_id
+    FOREIGN KEY (folder_id)
+    REFERENCES folders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_feed_follow_id
+    FOREIGN KEY (feed_follow_id)
+    REFERENCES feed_follow(id) ON DELETE CASCADE
+);
+
+-- every category becomes a folder of the user
+INSERT INTO folders (created_at, updated_at, user_id, name)
+SELECT DISTINCT now(), now(), user_id, category
+FROM feed_follow
+WHERE user_id IS NOT NULL AND category IS NOT NULL;
+INSERT INTO folder_follows (folder_id, feed_follow_id)
+SELECT folders.id, feed_follow.id
+FROM feed_follow
+INNER JOIN folders
+ON folders.user_id = feed_follow.user_id AND folders.name = feed_follow.category;
+ALTER TABLE feed_follow DROP category;
+
+-- +goose Down
+ALTER TABLE feed_follow ADD category TEXT;
+UPDATE feed_follow
+SET category = (
+    SELECT MIN(folders.name)
+    FROM folders
+    INNER JOIN folder_follows ON folder_follows.folder_id = folders.id
+    WHERE folder_follows.feed_follow_id = feed_follow.id
+);
+DROP TABLE folder_follows;
+DROP TABLE folders;
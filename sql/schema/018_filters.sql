@@ -0,0 +1,45 @@
+-- +goose Up
+ALTER TABLE posts ADD author TEXT;
+
+CREATE TABLE filters(
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL,
+    -- rules without a feed apply to every followed feed
+    feed_id INTEGER,
+    field TEXT NOT NULL CHECK (field IN ('title', 'description', 'url', 'author')),
+    match_type TEXT NOT NULL CHECK (match_type IN ('substring', 'regex')),
+    pattern TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('hide', 'drop', 'highlight')),
+    CONSTRAINT fk_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_feed_id
+    FOREIGN KEY (feed_id)
+    REFERENCES feed(id) ON DELETE CASCADE
+);
+
+-- the one place rules are matched, so ingest, listing and filter test agree.
+-- Both kinds of match ignore case
+-- +goose StatementBegin
+CREATE FUNCTION filter_matches(field TEXT, match_type TEXT, pattern TEXT, title TEXT, description TEXT, url TEXT, author TEXT)
+RETURNS BOOLEAN
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT COALESCE(CASE match_type
+        WHEN 'regex' THEN target.value ~* pattern
+        ELSE strpos(lower(target.value), lower(pattern)) > 0
+    END, false)
+    FROM (SELECT CASE field
+        WHEN 'title' THEN title
+        WHEN 'description' THEN description
+        WHEN 'url' THEN url
+        WHEN 'author' THEN author
+    END AS value) AS target
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION filter_matches;
+DROP TABLE filters;
+ALTER TABLE posts DROP author;
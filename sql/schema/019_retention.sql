@@ -0,0 +1,10 @@
+-- +goose Up
+-- null limits fall back to the global retention
+ALTER TABLE feed ADD retention_max_age_seconds INTEGER;
+ALTER TABLE feed ADD retention_max_posts INTEGER;
+ALTER TABLE feed ADD keep_forever BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE feed DROP keep_forever;
+ALTER TABLE feed DROP retention_max_posts;
+ALTER TABLE feed DROP retention_max_age_seconds;
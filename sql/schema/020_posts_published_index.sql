@@ -0,0 +1,6 @@
+-- +goose Up
+-- browse pages through posts by (published_at, id)
+CREATE INDEX posts_published_at_id_idx ON posts (published_at, id);
+
+-- +goose Down
+DROP INDEX posts_published_at_id_idx;
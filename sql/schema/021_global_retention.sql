@@ -0,0 +1,12 @@
+-- +goose Up
+-- the retention of the feeds without their own limits, it has a single row so
+-- every agg process on the database prunes with the same limits
+CREATE TABLE retention(
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    max_age_seconds INTEGER,
+    max_posts INTEGER
+);
+INSERT INTO retention DEFAULT VALUES;
+
+-- +goose Down
+DROP TABLE retention;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- the guids of pruned posts, so fetching their feed again does not save them again.
+-- They are removed by prune once the feed's max age has passed
+CREATE TABLE pruned_posts(
+    feed_id INTEGER NOT NULL,
+    guid TEXT NOT NULL,
+    pruned_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (feed_id, guid),
+    CONSTRAINT fk_feed_id
+    FOREIGN KEY (feed_id)
+    REFERENCES feed(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE pruned_posts;
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+// how many starred posts are listed when no number is given
+const defaultStarredLimit = 20
+
+// star posts so they are kept, even when their feed is removed
+func handlerStar(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("star command requires a post id")
+	}
+
+	for _, arg := range cmd.args {
+		postID, err := parsePostID(arg)
+		if err != nil {
+			return err
+		}
+		starred, err := s.db.StarPost(s.ctx, database.StarPostParams{
+			CreatedAt: time.Now(),
+			UserID:    user.ID,
+			PostID:    postID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// nothing is inserted for missing posts, posts of feeds the user does not
+		// follow or posts that are already starred
+		if starred == 0 {
+			_, err := s.db.GetPostForUser(s.ctx, database.GetPostForUserParams{
+				ID:     postID,
+				UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("there is no post %d", postID)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Println("Already starred:", postID)
+			continue
+		}
+		fmt.Println("Starred:", postID)
+	}
+	return nil
+}
+
+// remove stars by post id, or by star id with --star for posts whose feed is gone
+func handlerUnstar(s *state, cmd command, user database.User) error {
+	fs := flag.NewFlagSet("unstar", flag.ContinueOnError)
+	byStar := fs.Bool("star", false, "the ids are star ids as shown by starred")
+	args, err := parseArgs(fs, cmd.args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("unstar command requires a post id")
+	}
+
+	for _, arg := range args {
+		id, err := parsePostID(arg)
+		if err != nil {
+			return err
+		}
+
+		var removed int64
+		if *byStar {
+			removed, err = s.db.DeleteStar(s.ctx, database.DeleteStarParams{ID: id, UserID: user.ID})
+		} else {
+			removed, err = s.db.UnstarPost(s.ctx, database.UnstarPostParams{
+				UserID: user.ID,
+				PostID: sql.NullInt32{Int32: id, Valid: true},
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		if removed == 0 {
+			fmt.Println("Not starred:", id)
+			continue
+		}
+		fmt.Println("Unstarred:", id)
+	}
+	return nil
+}
+
+// list the starred posts, newest star first
+func handlerStarred(s *state, cmd command, user database.User) error {
+	// set the number of posts if a number is given
+	limit := int32(defaultStarredLimit)
+	if len(cmd.args) > 0 {
+		n, err := strconv.ParseInt(cmd.args[0], 10, 32)
+		if err != nil || n < 1 {
+			return fmt.Errorf("the number of posts must be a positive number")
+		}
+		limit = int32(n)
+	}
+
+	stars, err := s.db.GetStarredPosts(s.ctx, database.GetStarredPostsParams{
+		UserID: user.ID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(stars) == 0 {
+		fmt.Println("No starred posts")
+		return nil
+	}
+
+	// posts of removed feeds only live on as the star, so they are shown by star id
+	for _, star := range stars {
+		if star.PostID.Valid {
+			fmt.Printf("-- Post %d\n", star.PostID.Int32)
+		} else {
+			fmt.Printf("-- Star %d (feed removed)\n", star.ID)
+		}
+		fmt.Println(star.Title)
+		fmt.Println("Feed:", star.FeedName.String)
+		fmt.Println("Published:", star.PublishedAt.Time)
+		fmt.Println("Link:", star.Url)
+		fmt.Println("Starred:", star.CreatedAt)
+	}
+	return nil
+}
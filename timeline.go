@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// how many posts are rendered when no number is given
+	defaultTimelineLimit = 20
+	// the channel link of generated RSS feeds, RSS 2.0 requires one
+	gatorHomePage = "https://github.com/EentErt/gator"
+)
+
+// Atom 1.0 document written for a user's timeline
+type timelineAtomFeed struct {
+	XMLName xml.Name            `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string              `xml:"id"`
+	Title   string              `xml:"title"`
+	Updated string              `xml:"updated"`
+	Author  timelineAtomPerson  `xml:"author"`
+	Link    timelineAtomLink    `xml:"link"`
+	Entries []timelineAtomEntry `xml:"entry"`
+}
+
+type timelineAtomEntry struct {
+	ID        string             `xml:"id"`
+	Title     string             `xml:"title"`
+	Link      timelineAtomLink   `xml:"link"`
+	Published string             `xml:"published"`
+	Updated   string             `xml:"updated"`
+	Summary   *timelineAtomText  `xml:"summary,omitempty"`
+	Source    timelineAtomSource `xml:"source"`
+}
+
+// the feed an entry was aggregated from
+type timelineAtomSource struct {
+	ID    string           `xml:"id"`
+	Title string           `xml:"title"`
+	Link  timelineAtomLink `xml:"link"`
+}
+
+type timelineAtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type timelineAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type timelineAtomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// RSS 2.0 document written for a user's timeline
+type timelineRSS struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		Title         string            `xml:"title"`
+		Link          string            `xml:"link"`
+		Description   string            `xml:"description"`
+		LastBuildDate string            `xml:"lastBuildDate"`
+		Items         []timelineRSSItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type timelineRSSItem struct {
+	Title       string            `xml:"title"`
+	Link        string            `xml:"link,omitempty"`
+	Description string            `xml:"description,omitempty"`
+	PubDate     string            `xml:"pubDate"`
+	GUID        timelineRSSGUID   `xml:"guid"`
+	Source      timelineRSSSource `xml:"source"`
+}
+
+type timelineRSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// the feed an item was aggregated from
+type timelineRSSSource struct {
+	URL  string `xml:"url,attr"`
+	Name string `xml:",chardata"`
+}
+
+// render a user's timeline as an "atom" or "rss" document
+func renderTimeline(format string, user database.User, posts []database.GetPostsForUserRow) ([]byte, error) {
+	var doc any
+	switch format {
+	case "atom":
+		doc = timelineAtomDoc(user, posts)
+	case "rss":
+		doc = timelineRSSDoc(user, posts)
+	default:
+		return nil, fmt.Errorf("unknown timeline format %q, use atom or rss", format)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	data = append([]byte(xml.Header), data...)
+	return append(data, '\n'), nil
+}
+
+// build the Atom document for a timeline
+func timelineAtomDoc(user database.User, posts []database.GetPostsForUserRow) timelineAtomFeed {
+	feed := timelineAtomFeed{
+		ID:      "tag:gator,2025:timeline:" + user.ID.String(),
+		Title:   "gator timeline of " + user.Name,
+		Updated: timelineUpdated(posts).Format(time.RFC3339),
+		Author:  timelineAtomPerson{Name: user.Name},
+		Link:    timelineAtomLink{Href: gatorHomePage},
+	}
+
+	for _, post := range posts {
+		entry := timelineAtomEntry{
+			ID:        timelineEntryID(post),
+			Title:     post.Title,
+			Link:      timelineAtomLink{Href: post.Url, Rel: "alternate"},
+			Published: post.PublishedAt.Time.Format(time.RFC3339),
+			Updated:   post.UpdatedAt.Time.Format(time.RFC3339),
+			Source: timelineAtomSource{
+				ID:    post.FeedUrl.String,
+				Title: post.FeedName.String,
+				Link:  timelineAtomLink{Href: timelineSourceLink(post), Rel: "alternate"},
+			},
+		}
+		if post.Description.Valid {
+			entry.Summary = &timelineAtomText{Type: "html", Text: post.Description.String}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// build the RSS 2.0 document for a timeline
+func timelineRSSDoc(user database.User, posts []database.GetPostsForUserRow) timelineRSS {
+	rss := timelineRSS{Version: "2.0"}
+	rss.Channel.Title = "gator timeline of " + user.Name
+	rss.Channel.Link = gatorHomePage
+	rss.Channel.Description = "Posts from the feeds followed by " + user.Name
+	rss.Channel.LastBuildDate = timelineUpdated(posts).Format(time.RFC1123Z)
+
+	for _, post := range posts {
+		rss.Channel.Items = append(rss.Channel.Items, timelineRSSItem{
+			Title:       post.Title,
+			Link:        post.Url,
+			Description: post.Description.String,
+			PubDate:     post.PublishedAt.Time.Format(time.RFC1123Z),
+			GUID:        timelineRSSGUID{Value: timelineEntryID(post)},
+			Source:      timelineRSSSource{URL: post.FeedUrl.String, Name: post.FeedName.String},
+		})
+	}
+	return rss
+}
+
+// keep the publisher's id when it is an absolute uri, otherwise derive a
+// stable tag uri from the feed url and guid
+func timelineEntryID(post database.GetPostsForUserRow) string {
+	if id, err := url.Parse(post.Guid); err == nil && id.IsAbs() {
+		return post.Guid
+	}
+	sum := sha1.Sum([]byte(post.FeedUrl.String + "\n" + post.Guid))
+	return "tag:gator,2025:post:" + hex.EncodeToString(sum[:])
+}
+
+// link to the source's web site, falling back to the feed itself
+func timelineSourceLink(post database.GetPostsForUserRow) string {
+	if post.FeedSiteUrl.Valid {
+		return post.FeedSiteUrl.String
+	}
+	return post.FeedUrl.String
+}
+
+// the time of the most recent change in the timeline
+func timelineUpdated(posts []database.GetPostsForUserRow) time.Time {
+	updated := time.Time{}
+	for _, post := range posts {
+		if post.UpdatedAt.Time.After(updated) {
+			updated = post.UpdatedAt.Time
+		}
+	}
+	if updated.IsZero() {
+		return time.Now()
+	}
+	return updated
+}
+
+// write the user's timeline as an Atom or RSS feed
+func handlerTimeline(s *state, cmd command, user database.User) error {
+	if len(cmd.args) < 1 {
+		return fmt.Errorf("timeline command requires a format, atom or rss")
+	}
+
+	// set the number of posts if a number is given
+	limit := int32(defaultTimelineLimit)
+	if len(cmd.args) > 1 {
+		n, err := strconv.ParseInt(cmd.args[1], 10, 32)
+		if err != nil {
+			return err
+		}
+		limit = int32(n)
+	}
+
+	// get the posts
+	posts, err := s.db.GetPostsForUser(s.ctx, database.GetPostsForUserParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Limit:  limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := renderTimeline(cmd.args[0], user, posts)
+	if err != nil {
+		return err
+	}
+
+	// write to the given file, or to stdout
+	if len(cmd.args) > 2 {
+		if err := os.WriteFile(cmd.args[2], data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d posts to %s\n", len(posts), cmd.args[2])
+		return nil
+	}
+	s.wroteDocument = true
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,552 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gator/internal/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// the address web listens on when none is given
+	defaultWebAddr = "localhost:8081"
+	// how many posts are shown on a page
+	webPageSize = 25
+)
+
+// what a page template is rendered with
+type webView struct {
+	Title string
+	User  string
+	CSRF  string
+	Path  string
+	Posts []webPost
+	Post  *webPost
+	Feed  *webFeed
+	Feeds []webFeed
+	// the previous and next page numbers, 0 if there is none
+	PrevPage int
+	NextPage int
+	Error    string
+}
+
+type webPost struct {
+	ID          int32
+	Title       string
+	URL         string
+	FeedID      int32
+	FeedName    string
+	PublishedAt sql.NullTime
+	Description template.HTML
+	Highlighted bool
+}
+
+type webFeed struct {
+	ID       int32
+	Name     string
+	URL      string
+	SiteURL  string
+	Folders  []string
+	Followed bool
+}
+
+// serve the html reader for the logged in user until the program is stopped
+func handlerWeb(s *state, cmd command, user database.User) error {
+	addr := defaultWebAddr
+	if len(cmd.args) > 0 {
+		addr = cmd.args[0]
+	}
+
+	// forms must carry this token, so other sites can not post them
+	csrf := make([]byte, 16)
+	if _, err := rand.Read(csrf); err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving the reader for %s on http://%s/\n", user.Name, addr)
+	return listenAndServe(s, addr, newWebMux(s, user, hex.EncodeToString(csrf)))
+}
+
+// register the reader routes
+func newWebMux(s *state, user database.User, csrf string) *http.ServeMux {
+	page := func(handler func(s *state, r *http.Request, user database.User, view *webView) (string, error)) http.Handler {
+		return webPage(s, user, csrf, handler)
+	}
+	action := func(handler func(s *state, r *http.Request, user database.User) (string, error)) http.Handler {
+		return webAction(s, user, csrf, handler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", page(webTimeline))
+	mux.Handle("GET /feeds", page(webFeeds))
+	mux.Handle("GET /feeds/{feedID}", page(webFeedPosts))
+	mux.Handle("GET /posts/{postID}", page(webPostDetail))
+	mux.Handle("POST /follow", action(webFollow))
+	mux.Handle("POST /unfollow", action(webUnfollow))
+	mux.Handle("/", page(func(s *state, r *http.Request, user database.User, view *webView) (string, error) {
+		return "", newAPIError(http.StatusNotFound, "page not found")
+	}))
+	return mux
+}
+
+// render the template a handler returns, or an error page
+func webPage(s *state, user database.User, csrf string, handler func(s *state, r *http.Request, user database.User, view *webView) (string, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		view := &webView{User: user.Name, CSRF: csrf, Path: r.URL.Path}
+		name, err := handler(s, r, user, view)
+		status := http.StatusOK
+		if err != nil {
+			status, view.Error = webErrorStatus(err)
+			view.Title = http.StatusText(status)
+			name = "error"
+		}
+		writeWebPage(w, status, name, view)
+	})
+}
+
+// run a form action then redirect to the page it returns
+func webAction(s *state, user database.User, csrf string, handler func(s *state, r *http.Request, user database.User) (string, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err == nil && subtle.ConstantTimeCompare([]byte(r.PostFormValue("csrf")), []byte(csrf)) != 1 {
+			err = newAPIError(http.StatusForbidden, "the form has expired, reload the page and try again")
+		}
+		next := ""
+		if err == nil {
+			next, err = handler(s, r, user)
+		}
+		if err != nil {
+			view := &webView{User: user.Name, CSRF: csrf, Path: r.URL.Path}
+			status, message := webErrorStatus(err)
+			view.Title = http.StatusText(status)
+			view.Error = message
+			writeWebPage(w, status, "error", view)
+			return
+		}
+		http.Redirect(w, r, next, http.StatusSeeOther)
+	})
+}
+
+// the status and message shown for an error, unexpected errors are logged and hidden
+func webErrorStatus(err error) (int, string) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, apiErr.Message
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound, "not found"
+	}
+	log.Println("web error:", err)
+	return http.StatusInternalServerError, "something went wrong, see the gator output for details"
+}
+
+// write a page, feed content is sanitized but the policy keeps scripts out regardless
+func writeWebPage(w http.ResponseWriter, status int, name string, view *webView) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src * data:; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+	if err := webTemplates[name].Execute(w, view); err != nil {
+		log.Println("rendering", name+":", err)
+	}
+}
+
+// GET / with the newest posts of the followed feeds
+func webTimeline(s *state, r *http.Request, user database.User, view *webView) (string, error) {
+	pageNumber, offset, err := webPageOffset(r)
+	if err != nil {
+		return "", err
+	}
+
+	// get one more post than is shown to know if there is a next page
+	posts, err := s.db.GetPostsForUser(r.Context(), database.GetPostsForUserParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Limit:  webPageSize + 1,
+		Offset: offset,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, post := range posts {
+		view.Posts = append(view.Posts, webPost{
+			ID:          post.ID,
+			Title:       post.Title,
+			URL:         post.Url,
+			FeedID:      post.FeedID,
+			FeedName:    post.FeedName.String,
+			PublishedAt: post.PublishedAt,
+			Highlighted: post.IsHighlighted,
+		})
+	}
+	view.Title = "Timeline"
+	webPager(view, pageNumber)
+	return "posts", nil
+}
+
+// GET /feeds with the followed feeds first, then every other feed
+func webFeeds(s *state, r *http.Request, user database.User, view *webView) (string, error) {
+	follows, err := s.db.GetFeedFollowsForUser(r.Context(), user.Name)
+	if err != nil {
+		return "", err
+	}
+	feeds, err := s.db.GetFeeds(r.Context())
+	if err != nil {
+		return "", err
+	}
+
+	followed := map[int32]bool{}
+	for _, follow := range follows {
+		followed[follow.FeedID] = true
+		view.Feeds = append(view.Feeds, webFeed{
+			ID:       follow.FeedID,
+			Name:     follow.FeedName.String,
+			URL:      follow.FeedUrl.String,
+			SiteURL:  follow.SiteUrl.String,
+			Folders:  follow.Folders,
+			Followed: true,
+		})
+	}
+	for _, feed := range feeds {
+		if !followed[feed.ID] {
+			view.Feeds = append(view.Feeds, toWebFeed(feed, false))
+		}
+	}
+	view.Title = "Feeds"
+	return "feeds", nil
+}
+
+// GET /feeds/{feedID} with the newest posts of one feed
+func webFeedPosts(s *state, r *http.Request, user database.User, view *webView) (string, error) {
+	feedID, err := webPathID(r, "feedID")
+	if err != nil {
+		return "", err
+	}
+	pageNumber, offset, err := webPageOffset(r)
+	if err != nil {
+		return "", err
+	}
+
+	feed, err := s.db.GetFeedById(r.Context(), feedID)
+	if err != nil {
+		return "", err
+	}
+	_, err = s.db.GetFeedFollow(r.Context(), database.GetFeedFollowParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		FeedID: sql.NullInt32{Int32: feed.ID, Valid: true},
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	feedView := toWebFeed(feed, err == nil)
+
+	posts, err := s.db.GetPostsForFeed(r.Context(), database.GetPostsForFeedParams{
+		FeedID: feed.ID,
+		Limit:  webPageSize + 1,
+		Offset: offset,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, post := range posts {
+		view.Posts = append(view.Posts, webPost{
+			ID:          post.ID,
+			Title:       post.Title,
+			URL:         post.Url,
+			FeedID:      feed.ID,
+			FeedName:    feedView.Name,
+			PublishedAt: post.PublishedAt,
+		})
+	}
+	view.Title = feedView.Name
+	view.Feed = &feedView
+	webPager(view, pageNumber)
+	return "posts", nil
+}
+
+// GET /posts/{postID} with the sanitized description of a post
+func webPostDetail(s *state, r *http.Request, user database.User, view *webView) (string, error) {
+	postID, err := webPathID(r, "postID")
+	if err != nil {
+		return "", err
+	}
+
+	// only posts of followed feeds are shown, others are not found
+	post, err := s.db.GetPostForUser(r.Context(), database.GetPostForUserParams{
+		ID:     postID,
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	view.Title = post.Title
+	view.Post = &webPost{
+		ID:          post.ID,
+		Title:       post.Title,
+		URL:         post.Url,
+		FeedID:      post.FeedID,
+		FeedName:    post.FeedName.String,
+		PublishedAt: post.PublishedAt,
+		Description: template.HTML(sanitizeHTML(post.Description.String, post.Url)),
+	}
+	return "post", nil
+}
+
+// POST /follow with the url of a feed
+func webFollow(s *state, r *http.Request, user database.User) (string, error) {
+	feedURL := strings.TrimSpace(r.PostFormValue("url"))
+	if feedURL == "" {
+		return "", newAPIError(http.StatusBadRequest, "enter the url of a feed to follow")
+	}
+
+	feed, err := s.db.GetFeedByUrl(r.Context(), sql.NullString{String: feedURL, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", newAPIError(http.StatusNotFound, "no feed with url %s, add it with gator addfeed first", feedURL)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// following a feed twice is not an error
+	userID := uuid.NullUUID{UUID: user.ID, Valid: true}
+	feedID := sql.NullInt32{Int32: feed.ID, Valid: true}
+	_, err = s.db.GetFeedFollow(r.Context(), database.GetFeedFollowParams{UserID: userID, FeedID: feedID})
+	if errors.Is(err, sql.ErrNoRows) {
+		timeNow := getNullTimeNow()
+		_, err = s.db.CreateFeedFollow(r.Context(), database.CreateFeedFollowParams{
+			CreatedAt: timeNow,
+			UpdatedAt: timeNow,
+			UserID:    userID,
+			FeedID:    feedID,
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+	return webRedirect(r), nil
+}
+
+// POST /unfollow with the id of a feed
+func webUnfollow(s *state, r *http.Request, user database.User) (string, error) {
+	feedID, err := strconv.ParseInt(r.PostFormValue("feed_id"), 10, 32)
+	if err != nil {
+		return "", newAPIError(http.StatusBadRequest, "invalid feed id %q", r.PostFormValue("feed_id"))
+	}
+
+	if err := s.db.DeleteFeedFollow(r.Context(), database.DeleteFeedFollowParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		FeedID: sql.NullInt32{Int32: int32(feedID), Valid: true},
+	}); err != nil {
+		return "", err
+	}
+	return webRedirect(r), nil
+}
+
+// go back to the page the form was on, only local paths are followed
+func webRedirect(r *http.Request) string {
+	next := r.PostFormValue("next")
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/feeds"
+	}
+	return next
+}
+
+// read an id from the path
+func webPathID(r *http.Request, name string) (int32, error) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 32)
+	if err != nil {
+		return 0, newAPIError(http.StatusNotFound, "page not found")
+	}
+	return int32(id), nil
+}
+
+// read the page number from the query and work out its offset
+func webPageOffset(r *http.Request) (int, int32, error) {
+	pageNumber := 1
+	if value := r.URL.Query().Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 100000 {
+			return 0, 0, newAPIError(http.StatusBadRequest, "invalid page %q", value)
+		}
+		pageNumber = n
+	}
+	return pageNumber, int32((pageNumber - 1) * webPageSize), nil
+}
+
+// set the pager links and drop the extra post that was fetched to find them
+func webPager(view *webView, pageNumber int) {
+	if pageNumber > 1 {
+		view.PrevPage = pageNumber - 1
+	}
+	if len(view.Posts) > webPageSize {
+		view.Posts = view.Posts[:webPageSize]
+		view.NextPage = pageNumber + 1
+	}
+}
+
+func toWebFeed(feed database.Feed, followed bool) webFeed {
+	return webFeed{
+		ID:       feed.ID,
+		Name:     feed.Name.String,
+		URL:      feed.Url.String,
+		SiteURL:  feed.SiteUrl.String,
+		Followed: followed,
+	}
+}
+
+// every page is the layout with its own content template
+var webTemplates = func() map[string]*template.Template {
+	funcs := template.FuncMap{
+		"date": func(t sql.NullTime) string {
+			if !t.Valid {
+				return ""
+			}
+			return t.Time.Format("2006-01-02 15:04")
+		},
+	}
+	layout := template.Must(template.New("layout").Funcs(funcs).Parse(webLayout))
+
+	templates := map[string]*template.Template{}
+	for name, content := range map[string]string{
+		"posts": webPostsTemplate,
+		"post":  webPostTemplate,
+		"feeds": webFeedsTemplate,
+		"error": webErrorTemplate,
+	} {
+		templates[name] = template.Must(template.Must(layout.Clone()).Parse(content))
+	}
+	return templates
+}()
+
+const webLayout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} - gator</title>
+<style>
+body { max-width: 46rem; margin: 0 auto; padding: 0 1rem 2rem; font-family: sans-serif; line-height: 1.5; color: #222; }
+nav { display: flex; gap: 1rem; align-items: baseline; padding: 1rem 0; border-bottom: 1px solid #ddd; }
+nav .user { margin-left: auto; color: #666; }
+a { color: #0645ad; }
+ul.posts, ul.feeds { list-style: none; padding: 0; }
+ul.posts li, ul.feeds li { padding: .5rem 0; border-bottom: 1px solid #eee; }
+.meta { color: #666; font-size: .9rem; }
+ul.posts li.highlight { background: #fff8d6; }
+.pager { display: flex; justify-content: space-between; padding: 1rem 0; }
+form.inline { display: inline; }
+article img { max-width: 100%; height: auto; }
+article pre { overflow-x: auto; }
+.error { color: #a00; }
+</style>
+</head>
+<body>
+<nav>
+<a href="/">Timeline</a>
+<a href="/feeds">Feeds</a>
+<span class="user">{{.User}}</span>
+</nav>
+<main>
+{{template "content" .}}
+</main>
+</body>
+</html>
+`
+
+const webPostsTemplate = `{{define "follow"}}{{if .Feed.Followed}}
+<form class="inline" method="post" action="/unfollow">
+<input type="hidden" name="csrf" value="{{.CSRF}}">
+<input type="hidden" name="next" value="{{.Path}}">
+<input type="hidden" name="feed_id" value="{{.Feed.ID}}">
+<button>Unfollow</button>
+</form>{{else}}
+<form class="inline" method="post" action="/follow">
+<input type="hidden" name="csrf" value="{{.CSRF}}">
+<input type="hidden" name="next" value="{{.Path}}">
+<input type="hidden" name="url" value="{{.Feed.URL}}">
+<button>Follow</button>
+</form>{{end}}{{end}}
+{{define "content"}}
+<h1>{{.Title}}</h1>
+{{with .Feed}}<p class="meta">
+<a href="{{.URL}}">{{.URL}}</a>{{if .SiteURL}} · <a href="{{.SiteURL}}" rel="noreferrer">web site</a>{{end}}
+</p>{{end}}
+{{if .Feed}}<p>{{template "follow" .}}{{if not .Feed.Followed}} to read its posts{{end}}</p>{{end}}
+{{if .Posts}}
+<ul class="posts">
+{{range .Posts}}<li{{if .Highlighted}} class="highlight"{{end}}>
+{{if or (not $.Feed) $.Feed.Followed}}<a href="/posts/{{.ID}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}<br>
+<span class="meta"><a href="/feeds/{{.FeedID}}">{{.FeedName}}</a>{{with date .PublishedAt}} · {{.}}{{end}}</span>
+</li>
+{{end}}</ul>
+{{else}}
+<p>No posts yet.{{if not .Feed}} <a href="/feeds">Follow some feeds</a> and run gator agg to fetch them.{{end}}</p>
+{{end}}
+<div class="pager">
+<span>{{if .PrevPage}}<a href="?page={{.PrevPage}}">Newer</a>{{end}}</span>
+<span>{{if .NextPage}}<a href="?page={{.NextPage}}">Older</a>{{end}}</span>
+</div>
+{{end}}
+`
+
+const webPostTemplate = `{{define "content"}}
+{{with .Post}}
+<article>
+<h1>{{.Title}}</h1>
+<p class="meta"><a href="/feeds/{{.FeedID}}">{{.FeedName}}</a>{{with date .PublishedAt}} · {{.}}{{end}}
+{{if .URL}} · <a href="{{.URL}}" rel="noreferrer">Original</a>{{end}}</p>
+{{.Description}}
+</article>
+{{end}}
+{{end}}
+`
+
+const webFeedsTemplate = `{{define "content"}}
+<h1>Feeds</h1>
+<form method="post" action="/follow">
+<input type="hidden" name="csrf" value="{{.CSRF}}">
+<input type="hidden" name="next" value="/feeds">
+<input type="url" name="url" placeholder="Feed url" required>
+<button>Follow</button>
+</form>
+{{$csrf := .CSRF}}
+<ul class="feeds">
+{{range .Feeds}}<li>
+<a href="/feeds/{{.ID}}">{{.Name}}</a>{{range .Folders}} <span class="meta">{{.}}</span>{{end}}<br>
+<span class="meta">{{.URL}}</span>
+{{if .Followed}}<form class="inline" method="post" action="/unfollow">
+<input type="hidden" name="csrf" value="{{$csrf}}">
+<input type="hidden" name="next" value="/feeds">
+<input type="hidden" name="feed_id" value="{{.ID}}">
+<button>Unfollow</button>
+</form>{{else}}<form class="inline" method="post" action="/follow">
+<input type="hidden" name="csrf" value="{{$csrf}}">
+<input type="hidden" name="next" value="/feeds">
+<input type="hidden" name="url" value="{{.URL}}">
+<button>Follow</button>
+</form>{{end}}
+</li>
+{{else}}<li>No feeds yet, add one with gator addfeed.</li>
+{{end}}</ul>
+{{end}}
+`
+
+const webErrorTemplate = `{{define "content"}}
+<h1>{{.Title}}</h1>
+<p class="error">{{.Error}}</p>
+<p><a href="/">Back to the timeline</a></p>
+{{end}}
+`